@@ -2,29 +2,63 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"slices"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pippokairos/workflow-monitor/internal/check"
 	"github.com/pippokairos/workflow-monitor/internal/config"
 	"github.com/pippokairos/workflow-monitor/internal/data"
 	"github.com/pippokairos/workflow-monitor/internal/debug"
+	"github.com/pippokairos/workflow-monitor/internal/doctor"
+	"github.com/pippokairos/workflow-monitor/internal/report"
 	"github.com/pippokairos/workflow-monitor/internal/ui"
+	"github.com/pippokairos/workflow-monitor/internal/wizard"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "report":
+			runReport(os.Args[2:])
+			return
+		case "check":
+			runCheck(os.Args[2:])
+			return
+		case "doctor":
+			runDoctor(os.Args[2:])
+			return
+		case "init":
+			runInit(os.Args[2:])
+			return
+		}
+	}
+
 	debugFlag := flag.Bool("debug", false, "Enable debug output")
+	configFlag, profileFlag := configFlags(flag.CommandLine)
+	refreshFlag := flag.Duration("refresh", 0, "Refresh the data automatically at this interval (e.g. 5m), overriding auto_refresh_interval")
 	flag.Parse()
 	debug.Enabled = *debugFlag
 
-	cfg, err := config.Load("config.yml")
+	cfg, err := loadConfig(*configFlag, *profileFlag)
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "refresh" {
+			cfg.AutoRefreshInterval = *refreshFlag
+		}
+	})
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid -refresh: %v", err)
+	}
+
 	debug.Printf("Config loaded successfully\n")
 	debug.Printf("Config: %+v", cfg)
 	debug.Printf("Atlassian URL: %s", cfg.AtlassianURL)
@@ -48,9 +82,192 @@ func main() {
 		return
 	}
 
-	p := tea.NewProgram(ui.InitialModel(fetcher), tea.WithAltScreen())
+	p := tea.NewProgram(ui.InitialModel(fetcher, cfg), tea.WithAltScreen(), tea.WithReportFocus())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error running program: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// configFlags registers the flags selecting the config file and profile, shared
+// by the TUI and the subcommands.
+func configFlags(flags *flag.FlagSet) (path, profile *string) {
+	path = flags.String("config", "", "Path to the config file (default: $"+config.EnvConfigPath+", then ./config.yml, then ~/.config/workflow-monitor/config.yml)")
+	profile = flags.String("profile", "", "Name of the config profile to apply")
+	return path, profile
+}
+
+func loadConfig(path, profile string) (*config.Config, error) {
+	path, err := config.Find(path)
+	if err != nil {
+		return nil, err
+	}
+
+	debug.Printf("Loading config from %s", path)
+	return config.Load(path, profile)
+}
+
+// runReport fetches everything once and prints the insights, e.g. for scripts or standups.
+func runReport(args []string) {
+	flags := flag.NewFlagSet("report", flag.ExitOnError)
+	format := flags.String("format", report.FormatJSON, "Output format: json, markdown or csv")
+	debugFlag := flags.Bool("debug", false, "Enable debug output")
+	configFlag, profileFlag := configFlags(flags)
+	flags.Parse(args)
+	debug.Enabled = *debugFlag
+
+	// Checked before spending any time fetching
+	if !slices.Contains(report.Formats, *format) {
+		log.Fatalf("Unknown report format: %s (expected: json, markdown or csv)", *format)
+	}
+
+	cfg, err := loadConfig(*configFlag, *profileFlag)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	fetcher, err := data.NewFetcher(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create data fetcher: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	insights, err := fetcher.FetchAll(ctx)
+	if err != nil {
+		log.Fatalf("Failed to fetch data: %v", err)
+	}
+
+	if err := report.New(insights, time.Now()).Write(os.Stdout, *format); err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+}
+
+// Exit codes of the check command.
+const (
+	checkExitOK       = 0
+	checkExitBreached = 1
+	checkExitError    = 2
+)
+
+// runCheck fetches everything once and exits non-zero when the configured
+// thresholds are breached, e.g. for cron jobs and CI.
+func runCheck(args []string) {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	debugFlag := flags.Bool("debug", false, "Enable debug output")
+	configFlag, profileFlag := configFlags(flags)
+	flags.Parse(args)
+	debug.Enabled = *debugFlag
+
+	// Failures must not be mistaken for breached thresholds
+	fail := func(format string, args ...any) {
+		log.Printf(format, args...)
+		os.Exit(checkExitError)
+	}
+
+	cfg, err := loadConfig(*configFlag, *profileFlag)
+	if err != nil {
+		fail("Failed to load config: %v", err)
+	}
+
+	fetcher, err := data.NewFetcher(cfg)
+	if err != nil {
+		fail("Failed to create data fetcher: %v", err)
+	}
+	thresholds := check.ThresholdsFromConfig(cfg)
+	fetcher.SetIssueLookbackDays(thresholds.IssueLookbackDays())
+
+	// Not deferred, os.Exit wouldn't run it
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	insights, err := fetcher.FetchAll(ctx)
+	cancel()
+	if err != nil {
+		fail("Failed to fetch data: %v", err)
+	}
+
+	result := check.Evaluate(insights, thresholds, time.Now())
+	if err := result.Write(os.Stdout); err != nil {
+		fail("Failed to write check result: %v", err)
+	}
+
+	if result.Breached() {
+		os.Exit(checkExitBreached)
+	}
+	os.Exit(checkExitOK)
+}
+
+// runDoctor checks the config against Jira and GitHub and prints what's wrong,
+// exiting with 1 when any check fails.
+func runDoctor(args []string) {
+	flags := flag.NewFlagSet("doctor", flag.ExitOnError)
+	debugFlag := flags.Bool("debug", false, "Enable debug output")
+	configFlag, profileFlag := configFlags(flags)
+	flags.Parse(args)
+	debug.Enabled = *debugFlag
+
+	// Config errors are reported like the other failed checks
+	var results []doctor.Result
+	path, err := config.Find(*configFlag)
+	var cfg *config.Config
+	if err == nil {
+		cfg, err = config.Load(path, *profileFlag)
+	}
+	if err != nil {
+		results = append(results, doctor.Result{Check: "Config", Status: doctor.StatusFail, Detail: err.Error()})
+	} else {
+		detail := "loaded from " + path
+		if *profileFlag != "" {
+			detail += " with profile " + *profileFlag
+		}
+		results = append(results, doctor.Result{Check: "Config", Status: doctor.StatusPass, Detail: detail})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		results = append(results, doctor.Run(ctx, cfg)...)
+		cancel()
+	}
+
+	if err := doctor.Write(os.Stdout, results); err != nil {
+		log.Fatalf("Failed to write doctor results: %v", err)
+	}
+
+	if doctor.Failed(results) {
+		os.Exit(1)
+	}
+}
+
+// runInit asks for the settings interactively and writes a new config file.
+func runInit(args []string) {
+	flags := flag.NewFlagSet("init", flag.ExitOnError)
+	debugFlag := flags.Bool("debug", false, "Enable debug output")
+	configFlag := flags.String("config", "", "Where to write the config file (default: ~/.config/workflow-monitor/config.yml)")
+	forceFlag := flags.Bool("force", false, "Overwrite the config file if it exists")
+	flags.Parse(args)
+	debug.Enabled = *debugFlag
+
+	path := *configFlag
+	if path == "" {
+		var err error
+		if path, err = config.DefaultPath(); err != nil {
+			log.Fatalf("Failed to find where to write the config: %v", err)
+		}
+	}
+
+	// Checked before asking anything
+	if _, err := os.Stat(path); err == nil && !*forceFlag {
+		log.Fatalf("%s already exists, use -force to overwrite it", path)
+	}
+
+	if err := wizard.Run(path); err != nil {
+		if errors.Is(err, wizard.ErrCancelled) {
+			os.Exit(1)
+		}
+		log.Fatalf("Failed to run the setup: %v", err)
+	}
+
+	doctorCmd := "workflow-monitor doctor"
+	if *configFlag != "" {
+		doctorCmd += " -config " + path
+	}
+	fmt.Printf("Wrote %s, run %s to check it.\n", path, doctorCmd)
+}
@@ -1,6 +1,8 @@
 package analyzer
 
 import (
+	"time"
+
 	"github.com/andygrunwald/go-jira"
 	"github.com/pippokairos/workflow-monitor/internal/config"
 	"github.com/pippokairos/workflow-monitor/internal/gh"
@@ -15,6 +17,7 @@ type Insights struct {
 type DoneNotMergedPR struct {
 	IssueID     string
 	PullRequest gh.PullRequest
+	DoneSince   time.Time // Zero when Jira doesn't tell
 }
 
 type ReviewNeededPR gh.PullRequest
@@ -57,6 +60,7 @@ func GetDoneNotMergedPRs(issues []jira.Issue, issueIDToOpenPRs map[string][]gh.P
 			doneNotMergedPRs = append(doneNotMergedPRs, DoneNotMergedPR{
 				IssueID:     issueID,
 				PullRequest: prs[j],
+				DoneSince:   statusCategoryChangedAt(&issues[i]),
 			})
 		}
 	}
@@ -100,3 +104,19 @@ func GetReviewedNotInQAPRs(issues []jira.Issue, issueIDToOpenPRs map[string][]gh
 
 	return reviewedNotInQAPRs
 }
+
+// statusCategoryChangedAt is when the issue last moved between to do, in progress
+// and done, or zero on Jira versions not reporting it.
+func statusCategoryChangedAt(issue *jira.Issue) time.Time {
+	changed, ok := issue.Fields.Unknowns["statuscategorychangedate"].(string)
+	if !ok {
+		return time.Time{}
+	}
+
+	t, err := time.Parse("2006-01-02T15:04:05.999-0700", changed)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
@@ -0,0 +1,225 @@
+package atlassian
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/pippokairos/workflow-monitor/internal/config"
+	"github.com/pippokairos/workflow-monitor/internal/debug"
+)
+
+// Overridden in tests.
+var (
+	oauthTokenURL = "https://auth.atlassian.com/oauth/token"
+	oauthAPIURL   = "https://api.atlassian.com/ex/jira/"
+)
+
+// newHTTPClient builds the authenticated HTTP client for the configured auth mode,
+// along with the base URL API calls have to be made against.
+func newHTTPClient(cfg *config.Config) (*http.Client, string, error) {
+	switch cfg.AtlassianAuth {
+	case config.AtlassianAuthBearer:
+		tp := jira.BearerAuthTransport{Token: cfg.AtlassianToken}
+		return tp.Client(), cfg.AtlassianURL, nil
+
+	case config.AtlassianAuthOAuth:
+		tp, err := newOAuthTransport(cfg)
+		if err != nil {
+			return nil, "", err
+		}
+		// OAuth 2.0 (3LO) apps reach Jira Cloud through the API gateway rather than the site URL
+		return &http.Client{Transport: tp}, oauthAPIURL + cfg.AtlassianCloudID, nil
+
+	default:
+		tp := jira.BasicAuthTransport{
+			Username: cfg.AtlassianEmail,
+			Password: cfg.AtlassianToken,
+		}
+		return tp.Client(), cfg.AtlassianURL, nil
+	}
+}
+
+type oauthToken struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// oauthTransport authenticates with the access token stored in the token file,
+// refreshing it (and rewriting the file, as refresh tokens rotate) when it expires.
+type oauthTransport struct {
+	tokenFile    string
+	clientID     string
+	clientSecret string
+
+	mu    sync.Mutex
+	token oauthToken
+}
+
+func newOAuthTransport(cfg *config.Config) (*oauthTransport, error) {
+	data, err := os.ReadFile(cfg.AtlassianOAuthTokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Atlassian OAuth token file: %w", err)
+	}
+
+	var token oauthToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse Atlassian OAuth token file: %w", err)
+	}
+
+	if token.AccessToken == "" && token.RefreshToken == "" {
+		return nil, fmt.Errorf("Atlassian OAuth token file contains neither an access nor a refresh token")
+	}
+
+	return &oauthTransport{
+		tokenFile:    cfg.AtlassianOAuthTokenFile,
+		clientID:     cfg.AtlassianOAuthClientID,
+		clientSecret: cfg.AtlassianOAuthClientSecret,
+		token:        token,
+	}, nil
+}
+
+func (t *oauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	accessToken, err := t.accessToken(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.send(req, accessToken)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// Tokens without an expiry date, or revoked early, are only known to be
+	// expired once rejected: refresh and retry once, if the body can be resent
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	refreshed, err := t.refreshRejected(req, accessToken)
+	if err != nil {
+		debug.Printf("Error refreshing rejected Atlassian OAuth token: %v", err)
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.send(retry, refreshed)
+}
+
+func (t *oauthTransport) send(req *http.Request, accessToken string) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func (t *oauthTransport) accessToken(req *http.Request) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	expired := !t.token.ExpiresAt.IsZero() && time.Until(t.token.ExpiresAt) < time.Minute
+	if t.token.AccessToken != "" && !expired {
+		return t.token.AccessToken, nil
+	}
+
+	if t.token.RefreshToken == "" {
+		return "", fmt.Errorf("Atlassian OAuth access token expired and no refresh token is available")
+	}
+
+	if err := t.refresh(req); err != nil {
+		return "", err
+	}
+
+	return t.token.AccessToken, nil
+}
+
+// refreshRejected refreshes the access token after it was rejected, unless a
+// concurrent request already did.
+func (t *oauthTransport) refreshRejected(req *http.Request, rejected string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token.AccessToken != rejected {
+		return t.token.AccessToken, nil
+	}
+
+	if t.token.RefreshToken == "" {
+		return "", fmt.Errorf("Atlassian OAuth access token rejected and no refresh token is available")
+	}
+
+	if err := t.refresh(req); err != nil {
+		return "", err
+	}
+
+	return t.token.AccessToken, nil
+}
+
+func (t *oauthTransport) refresh(req *http.Request) error {
+	body, err := json.Marshal(map[string]string{
+		"grant_type":    "refresh_token",
+		"client_id":     t.clientID,
+		"client_secret": t.clientSecret,
+		"refresh_token": t.token.RefreshToken,
+	})
+	if err != nil {
+		return err
+	}
+
+	refreshReq, err := http.NewRequestWithContext(req.Context(), http.MethodPost, oauthTokenURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	refreshReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(refreshReq)
+	if err != nil {
+		return fmt.Errorf("failed to refresh Atlassian OAuth token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to refresh Atlassian OAuth token: %s", resp.Status)
+	}
+
+	var result struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode Atlassian OAuth token: %w", err)
+	}
+
+	t.token.AccessToken = result.AccessToken
+	t.token.ExpiresAt = time.Time{}
+	if result.ExpiresIn > 0 {
+		t.token.ExpiresAt = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+	if result.RefreshToken != "" {
+		t.token.RefreshToken = result.RefreshToken
+	}
+	debug.Printf("Refreshed Atlassian OAuth token, expires at %s", t.token.ExpiresAt)
+
+	data, err := json.MarshalIndent(t.token, "", "  ")
+	if err == nil {
+		err = os.WriteFile(t.tokenFile, data, 0o600)
+	}
+	if err != nil {
+		debug.Printf("Error saving refreshed Atlassian OAuth token: %v", err)
+	}
+
+	return nil
+}
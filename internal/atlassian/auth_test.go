@@ -0,0 +1,198 @@
+package atlassian
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pippokairos/workflow-monitor/internal/config"
+)
+
+func TestNewHTTPClientBearer(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	httpClient, baseURL, err := newHTTPClient(&config.Config{
+		AtlassianURL:   server.URL,
+		AtlassianToken: "pat",
+		AtlassianAuth:  config.AtlassianAuthBearer,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	resp, err := httpClient.Get(baseURL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if gotAuth != "Bearer pat" {
+		t.Errorf("Expected 'Bearer pat', got '%s'", gotAuth)
+	}
+}
+
+func TestOAuthTransportRefreshesExpiredToken(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/token":
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["refresh_token"] != "old-refresh" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"access_token":"new-access","refresh_token":"new-refresh","expires_in":3600}`)
+		case "/ex/jira/cloud-id/rest/api/2/myself":
+			gotAuth = r.Header.Get("Authorization")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	defaultTokenURL, defaultAPIURL := oauthTokenURL, oauthAPIURL
+	defer func() { oauthTokenURL, oauthAPIURL = defaultTokenURL, defaultAPIURL }()
+	oauthTokenURL = server.URL + "/oauth/token"
+	oauthAPIURL = server.URL + "/ex/jira/"
+
+	tokenFile := filepath.Join(t.TempDir(), "token.json")
+	data, _ := json.Marshal(oauthToken{
+		AccessToken:  "old-access",
+		RefreshToken: "old-refresh",
+		ExpiresAt:    time.Now().Add(-time.Hour),
+	})
+	if err := os.WriteFile(tokenFile, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	httpClient, baseURL, err := newHTTPClient(&config.Config{
+		AtlassianAuth:           config.AtlassianAuthOAuth,
+		AtlassianCloudID:        "cloud-id",
+		AtlassianOAuthTokenFile: tokenFile,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	resp, err := httpClient.Get(baseURL + "/rest/api/2/myself")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if gotAuth != "Bearer new-access" {
+		t.Errorf("Expected 'Bearer new-access', got '%s'", gotAuth)
+	}
+
+	saved, err := os.ReadFile(tokenFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var token oauthToken
+	if err := json.Unmarshal(saved, &token); err != nil {
+		t.Fatal(err)
+	}
+	if token.RefreshToken != "new-refresh" {
+		t.Errorf("Expected the rotated refresh token to be saved, got '%s'", token.RefreshToken)
+	}
+}
+
+func TestOAuthTransportRefreshesRejectedToken(t *testing.T) {
+	tests := map[string]struct {
+		validToken    string
+		wantStatus    int
+		wantRefreshes int
+		wantRequests  int
+	}{
+		"token without expiry is refreshed on 401": {
+			validToken:    "new-access",
+			wantStatus:    http.StatusOK,
+			wantRefreshes: 1,
+			wantRequests:  2,
+		},
+		"retried only once": {
+			validToken:    "never",
+			wantStatus:    http.StatusUnauthorized,
+			wantRefreshes: 1,
+			wantRequests:  2,
+		},
+		"accepted token isn't refreshed": {
+			validToken:   "old-access",
+			wantStatus:   http.StatusOK,
+			wantRequests: 1,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var refreshes, requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/oauth/token":
+					refreshes++
+					fmt.Fprint(w, `{"access_token":"new-access","refresh_token":"new-refresh","expires_in":3600}`)
+				case "/ex/jira/cloud-id/rest/api/2/issue/PROJ-1/transitions":
+					requests++
+					if body, _ := io.ReadAll(r.Body); string(body) != `{"transition":{"id":"31"}}` {
+						t.Errorf("Expected the request body to be resent, got %q", body)
+					}
+					if r.Header.Get("Authorization") != "Bearer "+tt.validToken {
+						w.WriteHeader(http.StatusUnauthorized)
+					}
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			defaultTokenURL, defaultAPIURL := oauthTokenURL, oauthAPIURL
+			defer func() { oauthTokenURL, oauthAPIURL = defaultTokenURL, defaultAPIURL }()
+			oauthTokenURL = server.URL + "/oauth/token"
+			oauthAPIURL = server.URL + "/ex/jira/"
+
+			// No expires_at, the token can't be known to be expired beforehand
+			tokenFile := filepath.Join(t.TempDir(), "token.json")
+			if err := os.WriteFile(tokenFile, []byte(`{"access_token":"old-access","refresh_token":"old-refresh"}`), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			httpClient, baseURL, err := newHTTPClient(&config.Config{
+				AtlassianAuth:              config.AtlassianAuthOAuth,
+				AtlassianCloudID:           "cloud-id",
+				AtlassianOAuthTokenFile:    tokenFile,
+				AtlassianOAuthClientID:     "client-id",
+				AtlassianOAuthClientSecret: "client-secret",
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			resp, err := httpClient.Post(baseURL+"/rest/api/2/issue/PROJ-1/transitions", "application/json", strings.NewReader(`{"transition":{"id":"31"}}`))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+			if refreshes != tt.wantRefreshes {
+				t.Errorf("Expected %d refreshes, got %d", tt.wantRefreshes, refreshes)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("Expected %d requests, got %d", tt.wantRequests, requests)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package atlassian
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -9,48 +10,77 @@ import (
 	"github.com/pippokairos/workflow-monitor/internal/debug"
 )
 
+const (
+	searchPageSize        = 50
+	defaultSearchMaxPages = 10
+)
+
 type Client struct {
-	jira         *jira.Client
-	statusReview string
-	statusDone   string
-	projectKeys  []string
+	jira           *jira.Client
+	statusReview   string
+	statusDone     string
+	projectKeys    []string
+	searchMaxPages int
+	lookbackDays   int
+	searcher       *searcher
 }
 
 func NewClient(cfg *config.Config) (*Client, error) {
-	tp := jira.BasicAuthTransport{
-		Username: cfg.AtlassianEmail,
-		Password: cfg.AtlassianToken,
+	httpClient, baseURL, err := newHTTPClient(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	client, err := jira.NewClient(tp.Client(), cfg.AtlassianURL)
+	client, err := jira.NewClient(httpClient, baseURL)
 	if err != nil {
 		return nil, err
 	}
 
+	searchMaxPages := cfg.AtlassianSearchMaxPages
+	if searchMaxPages <= 0 {
+		searchMaxPages = defaultSearchMaxPages
+	}
+
 	return &Client{
-		jira:         client,
-		statusReview: cfg.AtlassianStatusReview,
-		statusDone:   cfg.AtlassianStatusDone,
-		projectKeys:  cfg.AtlassianProjectKeys,
+		jira:           client,
+		statusReview:   cfg.AtlassianStatusReview,
+		statusDone:     cfg.AtlassianStatusDone,
+		projectKeys:    cfg.AtlassianProjectKeys,
+		searchMaxPages: searchMaxPages,
+		lookbackDays:   config.IssueLookbackDays,
+		searcher:       newSearcher(cfg.AtlassianSearchAPI, baseURL),
 	}, nil
 }
 
-func (c *Client) FetchMyIssuesInReviewOrDone() ([]jira.Issue, error) {
-	jql := fmt.Sprintf("assignee = currentUser() AND updated >= -14d AND status IN (\"%s\", \"%s\")", c.statusReview, c.statusDone)
+// SetLookbackDays changes how many days back the updated tickets are searched,
+// config.IssueLookbackDays by default.
+func (c *Client) SetLookbackDays(days int) {
+	c.lookbackDays = days
+}
+
+func (c *Client) FetchMyIssuesInReviewOrDone(ctx context.Context) ([]jira.Issue, error) {
+	jql := fmt.Sprintf("assignee = currentUser() AND updated >= -%dd AND status IN (\"%s\", \"%s\")",
+		c.lookbackDays, c.statusReview, c.statusDone)
 	if len(c.projectKeys) > 0 {
 		jql += fmt.Sprintf(" AND project IN (%s)", strings.Join(c.projectKeys, ","))
 	}
 	jql += " ORDER BY updated DESC"
 
-	options := &jira.SearchOptionsV2{Fields: []string{"*all"}}
+	var issues []jira.Issue
+	var pages int
+	var err error
 
-	// TODO: handle pagination?
-	issues, resp, err := c.jira.Issue.SearchV2JQL(jql, options)
-	debug.Printf("Jira response: %+v", resp)
-	debug.Printf("Response: %+v", resp.Response)
+	api := c.searchAPI(ctx)
+	if api == config.AtlassianSearchAPICloud {
+		issues, pages, err = c.searchEnhanced(ctx, jql)
+	} else {
+		issues, pages, err = c.searchLegacy(ctx, jql)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	return issues, err
+	debug.Printf("Fetched %d Jira issues in %d page(s) using the %s search API", len(issues), pages, api)
+
+	return issues, nil
 }
@@ -0,0 +1,227 @@
+package atlassian
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pippokairos/workflow-monitor/internal/config"
+)
+
+func TestNewSearcher(t *testing.T) {
+	tests := map[string]struct {
+		mode     string
+		baseURL  string
+		wantMode string
+	}{
+		"cloud host": {
+			baseURL:  "https://owner.atlassian.net",
+			wantMode: config.AtlassianSearchAPICloud,
+		},
+		"self-hosted stays auto": {
+			baseURL:  "https://jira.example.com",
+			wantMode: config.AtlassianSearchAPIAuto,
+		},
+		"explicit legacy": {
+			mode:     config.AtlassianSearchAPILegacy,
+			baseURL:  "https://owner.atlassian.net",
+			wantMode: config.AtlassianSearchAPILegacy,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := newSearcher(tt.mode, tt.baseURL)
+			if s.mode != tt.wantMode {
+				t.Errorf("Expected mode '%s', got '%s'", tt.wantMode, s.mode)
+			}
+		})
+	}
+}
+
+func TestFetchMyIssuesInReviewOrDone(t *testing.T) {
+	tests := map[string]struct {
+		searchAPI string
+		maxPages  int
+		handler   http.HandlerFunc
+		wantKeys  []string
+	}{
+		"cloud follows next page token": {
+			searchAPI: config.AtlassianSearchAPICloud,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/rest/api/3/search/jql" {
+					http.NotFound(w, r)
+					return
+				}
+				if r.URL.Query().Get("nextPageToken") == "" {
+					fmt.Fprint(w, `{"issues":[{"key":"PROJ-1"}],"nextPageToken":"next"}`)
+					return
+				}
+				fmt.Fprint(w, `{"issues":[{"key":"PROJ-2"}],"isLast":true}`)
+			},
+			wantKeys: []string{"PROJ-1", "PROJ-2"},
+		},
+		"cloud stops at max pages": {
+			searchAPI: config.AtlassianSearchAPICloud,
+			maxPages:  1,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"issues":[{"key":"PROJ-1"}],"nextPageToken":"next"}`)
+			},
+			wantKeys: []string{"PROJ-1"},
+		},
+		"legacy follows start at": {
+			searchAPI: config.AtlassianSearchAPILegacy,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/rest/api/2/search" {
+					http.NotFound(w, r)
+					return
+				}
+				if r.URL.Query().Get("startAt") == "" {
+					fmt.Fprint(w, `{"issues":[{"key":"PROJ-1"}],"startAt":0,"total":2}`)
+					return
+				}
+				fmt.Fprint(w, `{"issues":[{"key":"PROJ-2"}],"startAt":1,"total":2}`)
+			},
+			wantKeys: []string{"PROJ-1", "PROJ-2"},
+		},
+		"auto detects cloud deployment": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/rest/api/2/serverInfo":
+					fmt.Fprint(w, `{"deploymentType":"Cloud"}`)
+				case "/rest/api/3/search/jql":
+					fmt.Fprint(w, `{"issues":[{"key":"PROJ-1"}],"isLast":true}`)
+				default:
+					http.NotFound(w, r)
+				}
+			},
+			wantKeys: []string{"PROJ-1"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			client, err := NewClient(&config.Config{
+				AtlassianURL:            server.URL,
+				AtlassianEmail:          "test@example.com",
+				AtlassianToken:          "token",
+				AtlassianSearchAPI:      tt.searchAPI,
+				AtlassianSearchMaxPages: tt.maxPages,
+			})
+			if err != nil {
+				t.Fatalf("NewClient failed: %v", err)
+			}
+
+			issues, err := client.FetchMyIssuesInReviewOrDone(context.Background())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(issues) != len(tt.wantKeys) {
+				t.Fatalf("Expected %d issues, got %d", len(tt.wantKeys), len(issues))
+			}
+			for i := range issues {
+				if issues[i].Key != tt.wantKeys[i] {
+					t.Errorf("Expected issue '%s', got '%s'", tt.wantKeys[i], issues[i].Key)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchAPIDetectionRetries(t *testing.T) {
+	var probes int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/serverInfo":
+			probes++
+			if probes == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `{"deploymentType":"Cloud"}`)
+		case "/rest/api/2/search":
+			w.WriteHeader(http.StatusGone)
+		case "/rest/api/3/search/jql":
+			fmt.Fprint(w, `{"issues":[{"key":"PROJ-1"}],"isLast":true}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{
+		AtlassianURL:   server.URL,
+		AtlassianEmail: "test@example.com",
+		AtlassianToken: "token",
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	// A failed probe falls back to the legacy search for this search only
+	if _, err := client.FetchMyIssuesInReviewOrDone(context.Background()); err == nil {
+		t.Fatal("Expected the legacy search to fail")
+	}
+
+	for range 2 {
+		issues, err := client.FetchMyIssuesInReviewOrDone(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(issues) != 1 {
+			t.Errorf("Expected 1 issue, got %d", len(issues))
+		}
+	}
+
+	if probes != 2 {
+		t.Errorf("Expected the deployment type to be probed until detected, got %d probes", probes)
+	}
+}
+
+func TestFetchMyIssuesLookback(t *testing.T) {
+	tests := map[string]struct {
+		days    int
+		wantJQL string
+	}{
+		"default": {wantJQL: fmt.Sprintf("updated >= -%dd", config.IssueLookbackDays)},
+		"widened": {days: 21, wantJQL: "updated >= -21d"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var jql string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				jql = r.URL.Query().Get("jql")
+				fmt.Fprint(w, `{"issues":[],"isLast":true}`)
+			}))
+			defer server.Close()
+
+			client, err := NewClient(&config.Config{
+				AtlassianURL:       server.URL,
+				AtlassianEmail:     "test@example.com",
+				AtlassianToken:     "token",
+				AtlassianSearchAPI: config.AtlassianSearchAPICloud,
+			})
+			if err != nil {
+				t.Fatalf("NewClient failed: %v", err)
+			}
+			if tt.days > 0 {
+				client.SetLookbackDays(tt.days)
+			}
+
+			if _, err := client.FetchMyIssuesInReviewOrDone(context.Background()); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.Contains(jql, tt.wantJQL) {
+				t.Errorf("Expected the JQL to contain '%s', got '%s'", tt.wantJQL, jql)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package atlassian
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/pippokairos/workflow-monitor/internal/debug"
+)
+
+type IssueDetails struct {
+	Key         string
+	Summary     string
+	Status      string
+	Assignee    string
+	Priority    string
+	Description string
+}
+
+// FetchIssueDetails reads the fields shown in the detail pane. It goes through API v2,
+// where the description is plain text rather than a rich-text document.
+func (c *Client) FetchIssueDetails(ctx context.Context, issueID string) (*IssueDetails, error) {
+	options := &jira.GetQueryOptions{Fields: "summary,status,assignee,priority,description"}
+
+	issue, resp, err := c.jira.Issue.GetWithContext(ctx, issueID, options)
+	debug.Printf("Jira GetIssue response: %+v", resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", issueID, err)
+	}
+
+	details := &IssueDetails{Key: issue.Key}
+	if issue.Fields == nil {
+		return details, nil
+	}
+
+	details.Summary = issue.Fields.Summary
+	details.Description = issue.Fields.Description
+	if issue.Fields.Status != nil {
+		details.Status = issue.Fields.Status.Name
+	}
+	if issue.Fields.Assignee != nil {
+		details.Assignee = issue.Fields.Assignee.DisplayName
+	}
+	if issue.Fields.Priority != nil {
+		details.Priority = issue.Fields.Priority.Name
+	}
+
+	return details, nil
+}
@@ -0,0 +1,82 @@
+package atlassian
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/pippokairos/workflow-monitor/internal/debug"
+)
+
+type Project struct {
+	Key  string
+	Name string
+}
+
+// ListProjects returns the projects visible to the user.
+func (c *Client) ListProjects(ctx context.Context) ([]Project, error) {
+	list, resp, err := c.jira.Project.GetListWithContext(ctx)
+	debug.Printf("Jira GetProjectList response: %+v", resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	projects := make([]Project, len(*list))
+	for i, project := range *list {
+		projects[i] = Project{Key: project.Key, Name: project.Name}
+	}
+
+	return projects, nil
+}
+
+// Myself returns the name of the user the credentials belong to.
+func (c *Client) Myself(ctx context.Context) (string, error) {
+	user, resp, err := c.jira.User.GetSelfWithContext(ctx)
+	debug.Printf("Jira GetSelf response: %+v", resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch the current user: %w", err)
+	}
+
+	switch {
+	case user.DisplayName != "":
+		return user.DisplayName, nil
+	case user.EmailAddress != "":
+		return user.EmailAddress, nil
+	default:
+		return user.Name, nil
+	}
+}
+
+// ProjectStatuses returns the statuses of the workflows used by the project's issue types.
+func (c *Client) ProjectStatuses(ctx context.Context, projectKey string) ([]string, error) {
+	req, err := c.jira.NewRequestWithContext(ctx, http.MethodGet, "rest/api/2/project/"+url.PathEscape(projectKey)+"/statuses", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var issueTypes []struct {
+		Statuses []jira.Status `json:"statuses"`
+	}
+	resp, err := c.jira.Do(req, &issueTypes)
+	debug.Printf("Jira GetProjectStatuses response: %+v", resp)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("project %s not found or not visible", projectKey)
+		}
+		return nil, fmt.Errorf("failed to fetch the statuses of %s: %w", projectKey, err)
+	}
+
+	var statuses []string
+	for _, issueType := range issueTypes {
+		for _, status := range issueType.Statuses {
+			if !slices.Contains(statuses, status.Name) {
+				statuses = append(statuses, status.Name)
+			}
+		}
+	}
+
+	return statuses, nil
+}
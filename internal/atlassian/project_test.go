@@ -0,0 +1,68 @@
+package atlassian
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/pippokairos/workflow-monitor/internal/config"
+)
+
+func TestProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/project":
+			fmt.Fprint(w, `[{"key":"PROJ","name":"Project"},{"key":"OPS","name":"Operations"}]`)
+		case "/rest/api/2/myself":
+			fmt.Fprint(w, `{"name":"jdoe","displayName":"Jane Doe"}`)
+		case "/rest/api/2/project/PROJ/statuses":
+			fmt.Fprint(w, `[
+				{"name":"Bug","statuses":[{"name":"To Do"},{"name":"Code Review"},{"name":"Done"}]},
+				{"name":"Task","statuses":[{"name":"To Do"},{"name":"Done"}]}
+			]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{
+		AtlassianURL:   server.URL,
+		AtlassianEmail: "test@example.com",
+		AtlassianToken: "token",
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	projects, err := client.ListProjects(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []Project{{"PROJ", "Project"}, {"OPS", "Operations"}}; !slices.Equal(projects, want) {
+		t.Errorf("Expected %v, got %v", want, projects)
+	}
+
+	name, err := client.Myself(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if name != "Jane Doe" {
+		t.Errorf("Expected Jane Doe, got %s", name)
+	}
+
+	statuses, err := client.ProjectStatuses(context.Background(), "PROJ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"To Do", "Code Review", "Done"}; !slices.Equal(statuses, want) {
+		t.Errorf("Expected %v, got %v", want, statuses)
+	}
+
+	if _, err := client.ProjectStatuses(context.Background(), "MISSING"); err == nil {
+		t.Error("Expected an error for a missing project")
+	}
+}
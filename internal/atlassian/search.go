@@ -0,0 +1,169 @@
+package atlassian
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/pippokairos/workflow-monitor/internal/config"
+	"github.com/pippokairos/workflow-monitor/internal/debug"
+)
+
+// Fields requested for every searched issue. The enhanced search endpoint returns
+// only IDs by default, and some fields (e.g. description) are rich-text documents in
+// API v3 that go-jira can't decode, so the list is kept explicit.
+var searchFields = []string{"summary", "status", "assignee", "priority", "project", "issuetype", "updated", "statuscategorychangedate"}
+
+// searcher resolves which Jira search endpoint should be used, detecting it on
+// the first search when the configured mode is auto.
+type searcher struct {
+	mode string
+
+	// Result of the detection, retried on every search until it succeeds
+	mu       sync.Mutex
+	detected bool
+	api      string
+}
+
+type enhancedSearchResult struct {
+	Issues        []jira.Issue `json:"issues"`
+	IsLast        bool         `json:"isLast"`
+	NextPageToken string       `json:"nextPageToken"`
+}
+
+type serverInfo struct {
+	DeploymentType string `json:"deploymentType"`
+}
+
+func newSearcher(mode, baseURL string) *searcher {
+	if mode == "" {
+		mode = config.AtlassianSearchAPIAuto
+	}
+
+	if mode == config.AtlassianSearchAPIAuto {
+		u, err := url.Parse(baseURL)
+		if err == nil && (strings.HasSuffix(u.Hostname(), ".atlassian.net") || u.Hostname() == "api.atlassian.com") {
+			mode = config.AtlassianSearchAPICloud
+		}
+	}
+
+	return &searcher{mode: mode}
+}
+
+func (c *Client) searchAPI(ctx context.Context) string {
+	if c.searcher.mode != config.AtlassianSearchAPIAuto {
+		return c.searcher.mode
+	}
+
+	c.searcher.mu.Lock()
+	defer c.searcher.mu.Unlock()
+
+	if c.searcher.detected {
+		return c.searcher.api
+	}
+
+	req, err := c.jira.NewRequestWithContext(ctx, http.MethodGet, "rest/api/2/serverInfo", nil)
+	if err != nil {
+		debug.Printf("Error building Jira serverInfo request: %v", err)
+		return config.AtlassianSearchAPILegacy
+	}
+
+	var info serverInfo
+	if _, err := c.jira.Do(req, &info); err != nil {
+		debug.Printf("Error detecting Jira deployment type, using legacy search until the next search: %v", err)
+		return config.AtlassianSearchAPILegacy
+	}
+
+	c.searcher.api = config.AtlassianSearchAPILegacy
+	if info.DeploymentType == "Cloud" {
+		c.searcher.api = config.AtlassianSearchAPICloud
+	}
+	c.searcher.detected = true
+
+	return c.searcher.api
+}
+
+// searchEnhanced walks the Jira Cloud /rest/api/3/search/jql endpoint using nextPageToken.
+func (c *Client) searchEnhanced(ctx context.Context, jql string) ([]jira.Issue, int, error) {
+	var allIssues []jira.Issue
+	var nextPageToken string
+	pages := 0
+
+	for {
+		uv := url.Values{}
+		uv.Add("jql", jql)
+		uv.Add("fields", strings.Join(searchFields, ","))
+		uv.Add("maxResults", strconv.Itoa(searchPageSize))
+		if nextPageToken != "" {
+			uv.Add("nextPageToken", nextPageToken)
+		}
+
+		u := url.URL{Path: "rest/api/3/search/jql", RawQuery: uv.Encode()}
+		req, err := c.jira.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, pages, err
+		}
+
+		var result enhancedSearchResult
+		resp, err := c.jira.Do(req, &result)
+		debug.Printf("Jira enhanced search response: %+v", resp)
+		if err != nil {
+			return nil, pages, jira.NewJiraError(resp, err)
+		}
+
+		pages++
+		allIssues = append(allIssues, result.Issues...)
+
+		if result.IsLast || result.NextPageToken == "" {
+			break
+		}
+
+		if pages >= c.searchMaxPages {
+			debug.Printf("Reached the limit of %d Jira search pages, more issues are available", c.searchMaxPages)
+			break
+		}
+
+		nextPageToken = result.NextPageToken
+	}
+
+	return allIssues, pages, nil
+}
+
+// searchLegacy walks the Jira Server/Data Center /rest/api/2/search endpoint using startAt.
+func (c *Client) searchLegacy(ctx context.Context, jql string) ([]jira.Issue, int, error) {
+	options := &jira.SearchOptions{
+		Fields:     searchFields,
+		MaxResults: searchPageSize,
+	}
+
+	var allIssues []jira.Issue
+	pages := 0
+
+	for {
+		issues, resp, err := c.jira.Issue.SearchWithContext(ctx, jql, options)
+		debug.Printf("Jira legacy search response: %+v", resp)
+		if err != nil {
+			return nil, pages, err
+		}
+
+		pages++
+		allIssues = append(allIssues, issues...)
+
+		if len(issues) == 0 || resp.StartAt+len(issues) >= resp.Total {
+			break
+		}
+
+		if pages >= c.searchMaxPages {
+			debug.Printf("Reached the limit of %d Jira search pages, more issues are available", c.searchMaxPages)
+			break
+		}
+
+		options.StartAt = resp.StartAt + len(issues)
+	}
+
+	return allIssues, pages, nil
+}
@@ -0,0 +1,58 @@
+package atlassian
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/andygrunwald/go-jira"
+	"github.com/pippokairos/workflow-monitor/internal/debug"
+)
+
+type Transition struct {
+	ID       string
+	Name     string
+	ToStatus string
+}
+
+func (c *Client) FetchTransitions(ctx context.Context, issueID string) ([]Transition, error) {
+	jiraTransitions, resp, err := c.jira.Issue.GetTransitionsWithContext(ctx, issueID)
+	debug.Printf("Jira GetTransitions response: %+v", resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transitions for %s: %w", issueID, err)
+	}
+
+	transitions := make([]Transition, len(jiraTransitions))
+	for i := range jiraTransitions {
+		transitions[i] = Transition{
+			ID:       jiraTransitions[i].ID,
+			Name:     jiraTransitions[i].Name,
+			ToStatus: jiraTransitions[i].To.Name,
+		}
+	}
+
+	return transitions, nil
+}
+
+func (c *Client) TransitionIssue(ctx context.Context, issueID, transitionID string) error {
+	resp, err := c.jira.Issue.DoTransitionWithContext(ctx, issueID, transitionID)
+	debug.Printf("Jira DoTransition response: %+v", resp)
+	if err != nil {
+		return fmt.Errorf("failed to transition %s: %w", issueID, err)
+	}
+
+	return nil
+}
+
+// FetchIssue re-reads a single issue with the same fields returned by the search.
+func (c *Client) FetchIssue(ctx context.Context, issueID string) (*jira.Issue, error) {
+	options := &jira.GetQueryOptions{Fields: strings.Join(searchFields, ",")}
+
+	issue, resp, err := c.jira.Issue.GetWithContext(ctx, issueID, options)
+	debug.Printf("Jira GetIssue response: %+v", resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", issueID, err)
+	}
+
+	return issue, nil
+}
@@ -0,0 +1,66 @@
+package atlassian
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pippokairos/workflow-monitor/internal/config"
+)
+
+func TestTransitions(t *testing.T) {
+	var transitioned string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue/PROJ-1/transitions" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			var payload struct {
+				Transition struct {
+					ID string `json:"id"`
+				} `json:"transition"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			transitioned = payload.Transition.ID
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		fmt.Fprint(w, `{"transitions":[{"id":"31","name":"Send to QA","to":{"name":"QA"}}]}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&config.Config{
+		AtlassianURL:   server.URL,
+		AtlassianEmail: "test@example.com",
+		AtlassianToken: "token",
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	transitions, err := client.FetchTransitions(context.Background(), "PROJ-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := Transition{ID: "31", Name: "Send to QA", ToStatus: "QA"}
+	if len(transitions) != 1 || transitions[0] != want {
+		t.Fatalf("Expected %+v, got %+v", want, transitions)
+	}
+
+	if err := client.TransitionIssue(context.Background(), "PROJ-1", "31"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if transitioned != "31" {
+		t.Errorf("Expected transition '31', got '%s'", transitioned)
+	}
+}
@@ -0,0 +1,106 @@
+package check
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pippokairos/workflow-monitor/internal/analyzer"
+	"github.com/pippokairos/workflow-monitor/internal/config"
+)
+
+// Thresholds breached by the insights. Nil ones aren't checked.
+type Thresholds struct {
+	MaxDoneNotMergedDays *int
+	MaxNeedReview        *int
+	MaxReadyForQA        *int
+}
+
+func ThresholdsFromConfig(cfg *config.Config) Thresholds {
+	return Thresholds{
+		MaxDoneNotMergedDays: cfg.CheckMaxDoneNotMergedDays,
+		MaxNeedReview:        cfg.CheckMaxNeedReview,
+		MaxReadyForQA:        cfg.CheckMaxReadyForQA,
+	}
+}
+
+// IssueLookbackDays returns how many days back the updated tickets must be
+// searched for the ones done for too long to be seen.
+func (t Thresholds) IssueLookbackDays() int {
+	if t.MaxDoneNotMergedDays == nil {
+		return config.IssueLookbackDays
+	}
+
+	// A ticket breaches once done for a whole day more than the threshold
+	return max(config.IssueLookbackDays, *t.MaxDoneNotMergedDays+1)
+}
+
+func (t Thresholds) empty() bool {
+	return t.MaxDoneNotMergedDays == nil && t.MaxNeedReview == nil && t.MaxReadyForQA == nil
+}
+
+type Result struct {
+	DoneNotMerged int
+	NeedReview    int
+	ReadyForQA    int
+	Breaches      []string
+
+	noThresholds bool
+}
+
+func (r *Result) Breached() bool {
+	return len(r.Breaches) > 0
+}
+
+func Evaluate(insights *analyzer.Insights, thresholds Thresholds, now time.Time) *Result {
+	r := &Result{
+		DoneNotMerged: len(insights.DoneNotMergedPRs),
+		NeedReview:    len(insights.NeedReviewPRs),
+		ReadyForQA:    len(insights.ReviewedNotInQAPRs),
+		noThresholds:  thresholds.empty(),
+	}
+
+	if limit := thresholds.MaxDoneNotMergedDays; limit != nil {
+		for _, item := range insights.DoneNotMergedPRs {
+			// Items Jira gave no date for can't be judged
+			if item.DoneSince.IsZero() {
+				continue
+			}
+
+			days := int(now.Sub(item.DoneSince).Hours() / 24)
+			if days > *limit {
+				r.Breaches = append(r.Breaches, fmt.Sprintf("%s has been done for %d days, but %s #%d isn't merged (max %d)",
+					item.IssueID, days, item.PullRequest.Repo, item.PullRequest.Number, *limit))
+			}
+		}
+	}
+
+	if limit := thresholds.MaxNeedReview; limit != nil && r.NeedReview > *limit {
+		r.Breaches = append(r.Breaches, fmt.Sprintf("%d PRs need your review (max %d)", r.NeedReview, *limit))
+	}
+
+	if limit := thresholds.MaxReadyForQA; limit != nil && r.ReadyForQA > *limit {
+		r.Breaches = append(r.Breaches, fmt.Sprintf("%d approved PRs have tickets not moved to QA (max %d)", r.ReadyForQA, *limit))
+	}
+
+	return r
+}
+
+// Write prints the counts of each category, followed by the breached thresholds.
+func (r *Result) Write(w io.Writer) error {
+	s := fmt.Sprintf("Ticket done, PRs not merged: %d\nNeed review: %d\nReady for QA: %d\n\n", r.DoneNotMerged, r.NeedReview, r.ReadyForQA)
+
+	switch {
+	case r.Breached():
+		for _, breach := range r.Breaches {
+			s += "FAIL: " + breach + "\n"
+		}
+	case r.noThresholds:
+		s += "OK: no thresholds configured\n"
+	default:
+		s += "OK: all thresholds met\n"
+	}
+
+	_, err := io.WriteString(w, s)
+	return err
+}
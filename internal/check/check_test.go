@@ -0,0 +1,113 @@
+package check
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pippokairos/workflow-monitor/internal/analyzer"
+	"github.com/pippokairos/workflow-monitor/internal/config"
+	"github.com/pippokairos/workflow-monitor/internal/gh"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	insights := &analyzer.Insights{
+		DoneNotMergedPRs: []analyzer.DoneNotMergedPR{
+			{IssueID: "PROJ-1", PullRequest: gh.PullRequest{Repo: "owner/repo", Number: 1}, DoneSince: now.Add(-5 * 24 * time.Hour)},
+			{IssueID: "PROJ-2", PullRequest: gh.PullRequest{Repo: "owner/repo", Number: 2}, DoneSince: now.Add(-24 * time.Hour)},
+			{IssueID: "PROJ-3", PullRequest: gh.PullRequest{Repo: "owner/repo", Number: 3}},
+			// Still fetched as it was updated lately, but done before the lookback window
+			{IssueID: "PROJ-6", PullRequest: gh.PullRequest{Repo: "owner/api", Number: 6}, DoneSince: now.Add(-30 * 24 * time.Hour)},
+		},
+		NeedReviewPRs: []analyzer.ReviewNeededPR{{Number: 4}, {Number: 5}},
+	}
+
+	tests := map[string]struct {
+		thresholds Thresholds
+		want       []string
+		wantOutput string
+	}{
+		"no thresholds": {
+			wantOutput: "OK: no thresholds configured",
+		},
+		"all thresholds met": {
+			thresholds: Thresholds{
+				MaxNeedReview: intPtr(2),
+				MaxReadyForQA: intPtr(0),
+			},
+			wantOutput: "OK: all thresholds met",
+		},
+		"done not merged for too long": {
+			thresholds: Thresholds{MaxDoneNotMergedDays: intPtr(3)},
+			want: []string{
+				"PROJ-1 has been done for 5 days, but owner/repo #1 isn't merged (max 3)",
+				"PROJ-6 has been done for 30 days, but owner/api #6 isn't merged (max 3)",
+			},
+			wantOutput: "FAIL: PROJ-1 has been done for 5 days",
+		},
+		"done before the lookback window": {
+			thresholds: Thresholds{MaxDoneNotMergedDays: intPtr(20)},
+			want:       []string{"PROJ-6 has been done for 30 days, but owner/api #6 isn't merged (max 20)"},
+			wantOutput: "FAIL: PROJ-6 has been done for 30 days",
+		},
+		"too many pending reviews": {
+			thresholds: Thresholds{MaxNeedReview: intPtr(1)},
+			want:       []string{"2 PRs need your review (max 1)"},
+			wantOutput: "FAIL: 2 PRs need your review (max 1)",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := Evaluate(insights, tt.thresholds, now)
+
+			if len(result.Breaches) != len(tt.want) {
+				t.Fatalf("Expected breaches %v, got %v", tt.want, result.Breaches)
+			}
+			for i := range tt.want {
+				if result.Breaches[i] != tt.want[i] {
+					t.Errorf("Expected breach '%s', got '%s'", tt.want[i], result.Breaches[i])
+				}
+			}
+			if result.Breached() != (len(tt.want) > 0) {
+				t.Errorf("Expected Breached() to be %t", len(tt.want) > 0)
+			}
+
+			var b strings.Builder
+			if err := result.Write(&b); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.HasPrefix(b.String(), "Ticket done, PRs not merged: 4\nNeed review: 2\nReady for QA: 0\n") {
+				t.Errorf("Unexpected summary:\n%s", b.String())
+			}
+			if !strings.Contains(b.String(), tt.wantOutput) {
+				t.Errorf("Expected output to contain '%s', got:\n%s", tt.wantOutput, b.String())
+			}
+		})
+	}
+}
+
+func TestIssueLookbackDays(t *testing.T) {
+	tests := map[string]struct {
+		thresholds Thresholds
+		want       int
+	}{
+		"no done threshold":          {thresholds: Thresholds{MaxNeedReview: intPtr(5)}, want: config.IssueLookbackDays},
+		"done threshold within":      {thresholds: Thresholds{MaxDoneNotMergedDays: intPtr(3)}, want: config.IssueLookbackDays},
+		"done threshold at the edge": {thresholds: Thresholds{MaxDoneNotMergedDays: intPtr(13)}, want: 14},
+		"done threshold beyond":      {thresholds: Thresholds{MaxDoneNotMergedDays: intPtr(20)}, want: 21},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.thresholds.IssueLookbackDays(); got != tt.want {
+				t.Errorf("Expected %d days, got %d", tt.want, got)
+			}
+		})
+	}
+}
@@ -3,43 +3,127 @@ package config
 
 import (
 	"fmt"
+	"maps"
+	"net/url"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	AtlassianAuthBasic  = "basic"
+	AtlassianAuthBearer = "bearer"
+	AtlassianAuthOAuth  = "oauth"
+
+	AtlassianSearchAPIAuto   = "auto"
+	AtlassianSearchAPICloud  = "cloud"
+	AtlassianSearchAPILegacy = "legacy"
+
+	GitHubAPIREST    = "rest"
+	GitHubAPIGraphQL = "graphql"
+
+	// Keeps automatic refreshes from eating the GitHub rate limit
+	MinAutoRefreshInterval = 30 * time.Second
+
+	// Jira tickets not updated for longer aren't fetched, unless the check command
+	// needs older ones
+	IssueLookbackDays = 14
+)
+
 type Config struct {
 	// Atlassian
-	AtlassianURL          string   `yaml:"atlassian_url"`
-	AtlassianEmail        string   `yaml:"atlassian_email"`
-	AtlassianToken        string   `yaml:"atlassian_token"`
-	AtlassianStatusReview string   `yaml:"atlassian_status_review"`
-	AtlassianStatusDone   string   `yaml:"atlassian_status_done"`
-	AtlassianProjectKeys  []string `yaml:"atlassian_project_keys"`
+	AtlassianURL               string   `yaml:"atlassian_url"`
+	AtlassianEmail             string   `yaml:"atlassian_email"`
+	AtlassianToken             string   `yaml:"atlassian_token"`
+	AtlassianAuth              string   `yaml:"atlassian_auth"`
+	AtlassianCloudID           string   `yaml:"atlassian_cloud_id"`
+	AtlassianOAuthTokenFile    string   `yaml:"atlassian_oauth_token_file"`
+	AtlassianOAuthClientID     string   `yaml:"atlassian_oauth_client_id"`
+	AtlassianOAuthClientSecret string   `yaml:"atlassian_oauth_client_secret"`
+	AtlassianStatusReview      string   `yaml:"atlassian_status_review"`
+	AtlassianStatusDone        string   `yaml:"atlassian_status_done"`
+	AtlassianProjectKeys       []string `yaml:"atlassian_project_keys"`
+	AtlassianSearchMaxPages    int      `yaml:"atlassian_search_max_pages"`
+	AtlassianSearchAPI         string   `yaml:"atlassian_search_api"`
 
 	// GitHub
-	GitHubUsername          string   `yaml:"github_username"`
-	GitHubToken             string   `yaml:"github_token"`
-	GitHubRequiredApprovers int      `yaml:"github_required_approvers"`
-	GitHubRepos             []string `yaml:"github_repos"`
+	GitHubUsername          string            `yaml:"github_username"`
+	GitHubToken             string            `yaml:"github_token"`
+	GitHubRequiredApprovers int               `yaml:"github_required_approvers"`
+	GitHubRepos             []string          `yaml:"github_repos"`
+	GitHubPerPage           int               `yaml:"github_per_page"`
+	GitHubAPI               string            `yaml:"github_api"`
+	GitHubMaxConcurrency    int               `yaml:"github_max_concurrency"`
+	GitHubCacheDir          string            `yaml:"github_cache_dir"`
+	GitHubBaseURL           string            `yaml:"github_base_url"`
+	GitHubUploadURL         string            `yaml:"github_upload_url"`
+	GitHubAppID             int64             `yaml:"github_app_id"`
+	GitHubAppPrivateKeyFile string            `yaml:"github_app_private_key_file"`
+	GitHubAppInstallationID int64             `yaml:"github_app_installation_id"`
+	GitHubMergeMethod       string            `yaml:"github_merge_method"`
+	GitHubMergeMethods      map[string]string `yaml:"github_merge_methods"`
 
 	// Matching
 	IssuePattern string `yaml:"issue_pattern"`
+
+	// TUI
+	AutoRefreshInterval time.Duration       `yaml:"auto_refresh_interval"`
+	Keybindings         map[string][]string `yaml:"keybindings"`
+
+	// Thresholds of the check command, unset ones aren't checked
+	CheckMaxDoneNotMergedDays *int `yaml:"check_max_done_not_merged_days"`
+	CheckMaxNeedReview        *int `yaml:"check_max_need_review"`
+	CheckMaxReadyForQA        *int `yaml:"check_max_ready_for_qa"`
 }
 
-func Load(path string) (*Config, error) {
+// configFile is the layout of the config file: the settings, optionally followed
+// by named profiles overriding some of them.
+type configFile struct {
+	Config   `yaml:",inline"`
+	Profiles map[string]yaml.Node `yaml:"profiles"`
+}
+
+// Load reads the config file, applying the named profile on top of the top-level
+// settings when profile isn't empty.
+func Load(path, profile string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
-	expanded := os.ExpandEnv(string(data))
+	expanded := expandEnv(string(data))
+
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(expanded), &document); err != nil {
+		return nil, err
+	}
 
-	var cfg Config
-	if err := yaml.Unmarshal([]byte(expanded), &cfg); err != nil {
+	var file configFile
+	if err := document.Decode(&file); err != nil {
 		return nil, err
 	}
 
+	cfg := file.Config
+	if profile != "" {
+		node, ok := file.Profiles[profile]
+		if !ok {
+			return nil, fmt.Errorf("profile %q not found in %s (available: %s)", profile, path, strings.Join(slices.Sorted(maps.Keys(file.Profiles)), ", "))
+		}
+
+		// overlayProfile only reads settings, anything else would be silently ignored
+		if node.Kind != yaml.MappingNode && node.ShortTag() != "!!null" {
+			return nil, fmt.Errorf("profile %q in %s must be a mapping of settings", profile, path)
+		}
+
+		cfg = Config{}
+		if err := overlayProfile(&document, &node).Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to read profile %q: %w", profile, err)
+		}
+	}
+
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("config validation failed: %w", err)
 	}
@@ -47,27 +131,130 @@ func Load(path string) (*Config, error) {
 	return &cfg, nil
 }
 
+// expandEnv replaces ${VAR} and $VAR with the values of the environment variables,
+// and $$ with a literal $.
+func expandEnv(s string) string {
+	return os.Expand(s, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		return os.Getenv(name)
+	})
+}
+
+// overlayProfile returns the top-level settings of the document with the ones the
+// profile sets replaced as a whole, where decoding the profile over them would
+// merge maps (e.g. keybindings) key by key.
+func overlayProfile(document, profile *yaml.Node) *yaml.Node {
+	overridden := make(map[string]bool)
+	for i := 0; i+1 < len(profile.Content); i += 2 {
+		overridden[profile.Content[i].Value] = true
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode}
+	root := document.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key := root.Content[i].Value
+		if key != "profiles" && !overridden[key] {
+			merged.Content = append(merged.Content, root.Content[i], root.Content[i+1])
+		}
+	}
+	merged.Content = append(merged.Content, profile.Content...)
+
+	return merged
+}
+
 func (cfg *Config) Validate() error {
 	if cfg.AtlassianURL == "" {
 		return fmt.Errorf("atlassian_url is required")
 	}
 
-	if cfg.AtlassianEmail == "" {
-		return fmt.Errorf("atlassian_email is required")
+	switch cfg.AtlassianAuth {
+	case "", AtlassianAuthBasic:
+		if cfg.AtlassianEmail == "" {
+			return fmt.Errorf("atlassian_email is required")
+		}
+
+		if cfg.AtlassianToken == "" {
+			return fmt.Errorf("atlassian_token is required")
+		}
+	case AtlassianAuthBearer:
+		if cfg.AtlassianToken == "" {
+			return fmt.Errorf("atlassian_token is required")
+		}
+	case AtlassianAuthOAuth:
+		if cfg.AtlassianOAuthTokenFile == "" || cfg.AtlassianCloudID == "" {
+			return fmt.Errorf("atlassian_oauth_token_file and atlassian_cloud_id are required for OAuth authentication")
+		}
+
+		if cfg.AtlassianOAuthClientID == "" || cfg.AtlassianOAuthClientSecret == "" {
+			return fmt.Errorf("atlassian_oauth_client_id and atlassian_oauth_client_secret are required to refresh OAuth tokens")
+		}
+	default:
+		return fmt.Errorf("atlassian_auth must be one of: basic, bearer, oauth")
+	}
+
+	if cfg.AtlassianSearchMaxPages < 0 {
+		return fmt.Errorf("atlassian_search_max_pages must not be negative")
 	}
 
-	if cfg.AtlassianToken == "" {
-		return fmt.Errorf("atlassian_token is required")
+	switch cfg.AtlassianSearchAPI {
+	case "", AtlassianSearchAPIAuto, AtlassianSearchAPICloud, AtlassianSearchAPILegacy:
+	default:
+		return fmt.Errorf("atlassian_search_api must be one of: auto, cloud, legacy")
 	}
 
 	if cfg.GitHubUsername == "" {
 		return fmt.Errorf("github_username is required")
 	}
 
-	if cfg.GitHubToken == "" {
+	if cfg.UsesGitHubApp() {
+		if cfg.GitHubAppID == 0 || cfg.GitHubAppPrivateKeyFile == "" || cfg.GitHubAppInstallationID == 0 {
+			return fmt.Errorf("github_app_id, github_app_private_key_file and github_app_installation_id are all required for GitHub App authentication")
+		}
+	} else if cfg.GitHubToken == "" {
 		return fmt.Errorf("github_token is required")
 	}
 
+	if cfg.GitHubPerPage < 0 || cfg.GitHubPerPage > 100 {
+		return fmt.Errorf("github_per_page must be between 1 and 100")
+	}
+
+	if cfg.GitHubMaxConcurrency < 0 {
+		return fmt.Errorf("github_max_concurrency must not be negative")
+	}
+
+	switch cfg.GitHubAPI {
+	case "", GitHubAPIREST, GitHubAPIGraphQL:
+	default:
+		return fmt.Errorf("github_api must be one of: rest, graphql")
+	}
+
+	if cfg.GitHubBaseURL != "" {
+		if err := validateHTTPURL(cfg.GitHubBaseURL); err != nil {
+			return fmt.Errorf("github_base_url %w", err)
+		}
+	}
+
+	if cfg.GitHubUploadURL != "" {
+		if cfg.GitHubBaseURL == "" {
+			return fmt.Errorf("github_upload_url requires github_base_url")
+		}
+		if err := validateHTTPURL(cfg.GitHubUploadURL); err != nil {
+			return fmt.Errorf("github_upload_url %w", err)
+		}
+	}
+
+	if err := validateMergeMethod(cfg.GitHubMergeMethod); err != nil {
+		return fmt.Errorf("github_merge_method %w", err)
+	}
+
+	for repo, method := range cfg.GitHubMergeMethods {
+		if err := validateMergeMethod(method); err != nil {
+			return fmt.Errorf("github_merge_methods[%s] %w", repo, err)
+		}
+	}
+
 	if cfg.IssuePattern == "" {
 		return fmt.Errorf("issue_pattern is required")
 	}
@@ -76,5 +263,63 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("at least one github_repo is required")
 	}
 
+	if cfg.AutoRefreshInterval != 0 && cfg.AutoRefreshInterval < MinAutoRefreshInterval {
+		return fmt.Errorf("auto_refresh_interval must be at least %s", MinAutoRefreshInterval)
+	}
+
+	if err := cfg.validateKeybindings(); err != nil {
+		return err
+	}
+
+	thresholds := []struct {
+		name  string
+		value *int
+	}{
+		{"check_max_done_not_merged_days", cfg.CheckMaxDoneNotMergedDays},
+		{"check_max_need_review", cfg.CheckMaxNeedReview},
+		{"check_max_ready_for_qa", cfg.CheckMaxReadyForQA},
+	}
+	for _, threshold := range thresholds {
+		if threshold.value != nil && *threshold.value < 0 {
+			return fmt.Errorf("%s must not be negative", threshold.name)
+		}
+	}
+
+	return nil
+}
+
+// UsesGitHubApp reports whether GitHub should be accessed as a GitHub App
+// installation instead of with github_token.
+func (cfg *Config) UsesGitHubApp() bool {
+	return cfg.GitHubAppID != 0 || cfg.GitHubAppPrivateKeyFile != "" || cfg.GitHubAppInstallationID != 0
+}
+
+func validateMergeMethod(method string) error {
+	switch method {
+	case "", "merge", "squash", "rebase":
+		return nil
+	default:
+		return fmt.Errorf("must be one of: merge, squash, rebase")
+	}
+}
+
+func validateHTTPURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("is not a valid URL: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("must start with http:// or https://")
+	}
+
+	if u.Host == "" {
+		return fmt.Errorf("must include a host")
+	}
+
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("must not include a query or fragment")
+	}
+
 	return nil
 }
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestLoad(t *testing.T) {
@@ -17,13 +21,14 @@ atlassian_project_keys:
   - PROJ
   - TEST
 
-github_token: gh-token
+github_token: gh$$token
 github_username: testuser
 github_repos:
   - owner/repo1
   - owner/repo2
 
 issue_pattern: '([A-Z]+-\d+)'
+auto_refresh_interval: 5m
 `
 	tmpfile, err := os.CreateTemp("", "config-*.yaml")
 	if err != nil {
@@ -38,7 +43,7 @@ issue_pattern: '([A-Z]+-\d+)'
 		t.Fatal(err)
 	}
 
-	cfg, err := Load(tmpfile.Name())
+	cfg, err := Load(tmpfile.Name(), "")
 	if err != nil {
 		t.Fatalf("Load failed: %v", err)
 	}
@@ -56,6 +61,10 @@ issue_pattern: '([A-Z]+-\d+)'
 		t.Errorf("Expected 2 project keys, got %d", len(cfg.AtlassianProjectKeys))
 	}
 
+	if cfg.GitHubToken != "gh$token" {
+		t.Errorf("Expected token 'gh$token', got '%s'", cfg.GitHubToken)
+	}
+
 	if cfg.GitHubUsername != "testuser" {
 		t.Errorf("Expected username 'testuser', got '%s'", cfg.GitHubUsername)
 	}
@@ -63,6 +72,121 @@ issue_pattern: '([A-Z]+-\d+)'
 	if len(cfg.GitHubRepos) != 2 {
 		t.Errorf("Expected 2 repos, got %d", len(cfg.GitHubRepos))
 	}
+
+	if cfg.AutoRefreshInterval != 5*time.Minute {
+		t.Errorf("Expected auto refresh interval 5m, got %s", cfg.AutoRefreshInterval)
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	content := `
+atlassian_url: https://test.atlassian.net
+atlassian_email: test@example.com
+atlassian_token: jira-token
+atlassian_project_keys:
+  - PROJ
+
+github_token: gh-token
+github_username: testuser
+github_repos:
+  - owner/repo1
+github_merge_methods:
+  owner/repo1: squash
+
+issue_pattern: '([A-Z]+-\d+)'
+
+keybindings:
+  refresh: [ctrl+r]
+  merge: [M]
+
+profiles:
+  oss:
+    atlassian_project_keys:
+      - OSS
+    github_repos:
+      - oss/repo
+    keybindings:
+      merge: [m]
+  broken:
+    github_per_page: 500
+  empty:
+  list:
+    - github_per_page: 50
+`
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		profile          string
+		wantErr          bool
+		wantProjects     []string
+		wantRepos        []string
+		wantKeybindings  map[string][]string
+		wantMergeMethods map[string]string
+	}{
+		"no profile": {
+			wantProjects:     []string{"PROJ"},
+			wantRepos:        []string{"owner/repo1"},
+			wantKeybindings:  map[string][]string{"refresh": {"ctrl+r"}, "merge": {"M"}},
+			wantMergeMethods: map[string]string{"owner/repo1": "squash"},
+		},
+		"profile overrides only its settings, maps as a whole": {
+			profile:          "oss",
+			wantProjects:     []string{"OSS"},
+			wantRepos:        []string{"oss/repo"},
+			wantKeybindings:  map[string][]string{"merge": {"m"}},
+			wantMergeMethods: map[string]string{"owner/repo1": "squash"},
+		},
+		"empty profile": {
+			profile:          "empty",
+			wantProjects:     []string{"PROJ"},
+			wantRepos:        []string{"owner/repo1"},
+			wantKeybindings:  map[string][]string{"refresh": {"ctrl+r"}, "merge": {"M"}},
+			wantMergeMethods: map[string]string{"owner/repo1": "squash"},
+		},
+		"profile not a mapping": {
+			profile: "list",
+			wantErr: true,
+		},
+		"unknown profile": {
+			profile: "work",
+			wantErr: true,
+		},
+		"profile is validated": {
+			profile: "broken",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := Load(path, tt.profile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !slices.Equal(cfg.AtlassianProjectKeys, tt.wantProjects) {
+				t.Errorf("AtlassianProjectKeys = %v, want %v", cfg.AtlassianProjectKeys, tt.wantProjects)
+			}
+			if !slices.Equal(cfg.GitHubRepos, tt.wantRepos) {
+				t.Errorf("GitHubRepos = %v, want %v", cfg.GitHubRepos, tt.wantRepos)
+			}
+			if cfg.GitHubToken != "gh-token" {
+				t.Errorf("GitHubToken = %q, want the top-level one", cfg.GitHubToken)
+			}
+			if !maps.EqualFunc(cfg.Keybindings, tt.wantKeybindings, slices.Equal) {
+				t.Errorf("Keybindings = %v, want %v", cfg.Keybindings, tt.wantKeybindings)
+			}
+			if !maps.Equal(cfg.GitHubMergeMethods, tt.wantMergeMethods) {
+				t.Errorf("GitHubMergeMethods = %v, want %v", cfg.GitHubMergeMethods, tt.wantMergeMethods)
+			}
+		})
+	}
 }
 
 func TestValidate(t *testing.T) {
@@ -123,6 +247,110 @@ func TestValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "atlassian_token is required",
 		},
+		"bearer auth without email": {
+			cfg: Config{
+				AtlassianURL:         "https://jira.example.com",
+				AtlassianToken:       "pat",
+				AtlassianAuth:        AtlassianAuthBearer,
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				IssuePattern:         `([A-Z]+-\d+)`,
+			},
+			wantErr: false,
+		},
+		"oauth auth without cloud id": {
+			cfg: Config{
+				AtlassianURL:            "https://test.atlassian.net",
+				AtlassianAuth:           AtlassianAuthOAuth,
+				AtlassianOAuthTokenFile: "token.json",
+				AtlassianProjectKeys:    []string{"PROJ"},
+				GitHubToken:             "gh-token",
+				GitHubUsername:          "user",
+				GitHubRepos:             []string{"owner/repo"},
+				IssuePattern:            `([A-Z]+-\d+)`,
+			},
+			wantErr: true,
+			errMsg:  "atlassian_oauth_token_file and atlassian_cloud_id are required for OAuth authentication",
+		},
+		"oauth auth without client credentials": {
+			cfg: Config{
+				AtlassianURL:            "https://test.atlassian.net",
+				AtlassianAuth:           AtlassianAuthOAuth,
+				AtlassianCloudID:        "cloud-id",
+				AtlassianOAuthTokenFile: "token.json",
+				AtlassianOAuthClientID:  "client-id",
+				AtlassianProjectKeys:    []string{"PROJ"},
+				GitHubToken:             "gh-token",
+				GitHubUsername:          "user",
+				GitHubRepos:             []string{"owner/repo"},
+				IssuePattern:            `([A-Z]+-\d+)`,
+			},
+			wantErr: true,
+			errMsg:  "atlassian_oauth_client_id and atlassian_oauth_client_secret are required to refresh OAuth tokens",
+		},
+		"oauth auth": {
+			cfg: Config{
+				AtlassianURL:               "https://test.atlassian.net",
+				AtlassianAuth:              AtlassianAuthOAuth,
+				AtlassianCloudID:           "cloud-id",
+				AtlassianOAuthTokenFile:    "token.json",
+				AtlassianOAuthClientID:     "client-id",
+				AtlassianOAuthClientSecret: "client-secret",
+				AtlassianProjectKeys:       []string{"PROJ"},
+				GitHubToken:                "gh-token",
+				GitHubUsername:             "user",
+				GitHubRepos:                []string{"owner/repo"},
+				IssuePattern:               `([A-Z]+-\d+)`,
+			},
+			wantErr: false,
+		},
+		"invalid atlassian auth": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianAuth:        "kerberos",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				IssuePattern:         `([A-Z]+-\d+)`,
+			},
+			wantErr: true,
+			errMsg:  "atlassian_auth must be one of: basic, bearer, oauth",
+		},
+		"negative atlassian search max pages": {
+			cfg: Config{
+				AtlassianURL:            "https://test.atlassian.net",
+				AtlassianEmail:          "test@example.com",
+				AtlassianToken:          "token",
+				AtlassianProjectKeys:    []string{"PROJ"},
+				AtlassianSearchMaxPages: -1,
+				GitHubToken:             "gh-token",
+				GitHubUsername:          "user",
+				GitHubRepos:             []string{"owner/repo"},
+				IssuePattern:            `([A-Z]+-\d+)`,
+			},
+			wantErr: true,
+			errMsg:  "atlassian_search_max_pages must not be negative",
+		},
+		"invalid atlassian search api": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				AtlassianSearchAPI:   "v4",
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				IssuePattern:         `([A-Z]+-\d+)`,
+			},
+			wantErr: true,
+			errMsg:  "atlassian_search_api must be one of: auto, cloud, legacy",
+		},
 		"missing github username": {
 			cfg: Config{
 				AtlassianURL:         "https://test.atlassian.net",
@@ -149,6 +377,35 @@ func TestValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "github_token is required",
 		},
+		"github app instead of token": {
+			cfg: Config{
+				AtlassianURL:            "https://test.atlassian.net",
+				AtlassianEmail:          "test@example.com",
+				AtlassianToken:          "token",
+				AtlassianProjectKeys:    []string{"PROJ"},
+				GitHubUsername:          "user",
+				GitHubAppID:             1,
+				GitHubAppPrivateKeyFile: "app.pem",
+				GitHubAppInstallationID: 2,
+				GitHubRepos:             []string{"owner/repo"},
+				IssuePattern:            `([A-Z]+-\d+)`,
+			},
+			wantErr: false,
+		},
+		"incomplete github app": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubUsername:       "user",
+				GitHubAppID:          1,
+				GitHubRepos:          []string{"owner/repo"},
+				IssuePattern:         `([A-Z]+-\d+)`,
+			},
+			wantErr: true,
+			errMsg:  "github_app_id, github_app_private_key_file and github_app_installation_id are all required for GitHub App authentication",
+		},
 		"missing github repos": {
 			cfg: Config{
 				AtlassianURL:         "https://test.atlassian.net",
@@ -163,6 +420,243 @@ func TestValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "at least one github_repo is required",
 		},
+		"github per page too large": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				GitHubPerPage:        101,
+				IssuePattern:         `([A-Z]+-\d+)`,
+			},
+			wantErr: true,
+			errMsg:  "github_per_page must be between 1 and 100",
+		},
+		"negative github max concurrency": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				GitHubMaxConcurrency: -1,
+				IssuePattern:         `([A-Z]+-\d+)`,
+			},
+			wantErr: true,
+			errMsg:  "github_max_concurrency must not be negative",
+		},
+		"invalid github api": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				GitHubAPI:            "soap",
+				IssuePattern:         `([A-Z]+-\d+)`,
+			},
+			wantErr: true,
+			errMsg:  "github_api must be one of: rest, graphql",
+		},
+		"invalid github base url scheme": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				GitHubBaseURL:        "github.example.com",
+				IssuePattern:         `([A-Z]+-\d+)`,
+			},
+			wantErr: true,
+			errMsg:  "github_base_url must start with http:// or https://",
+		},
+		"github base url without host": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				GitHubBaseURL:        "https://",
+				IssuePattern:         `([A-Z]+-\d+)`,
+			},
+			wantErr: true,
+			errMsg:  "github_base_url must include a host",
+		},
+		"github upload url without base url": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				GitHubUploadURL:      "https://github.example.com/api/uploads/",
+				IssuePattern:         `([A-Z]+-\d+)`,
+			},
+			wantErr: true,
+			errMsg:  "github_upload_url requires github_base_url",
+		},
+		"valid github enterprise urls": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				GitHubBaseURL:        "https://github.example.com/api/v3/",
+				GitHubUploadURL:      "https://github.example.com/api/uploads/",
+				IssuePattern:         `([A-Z]+-\d+)`,
+			},
+			wantErr: false,
+		},
+		"invalid github merge method for repo": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				GitHubMergeMethods:   map[string]string{"owner/repo": "octopus"},
+				IssuePattern:         `([A-Z]+-\d+)`,
+			},
+			wantErr: true,
+			errMsg:  "github_merge_methods[owner/repo] must be one of: merge, squash, rebase",
+		},
+		"auto refresh interval too short": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				IssuePattern:         `([A-Z]+-\d+)`,
+				AutoRefreshInterval:  10 * time.Second,
+			},
+			wantErr: true,
+			errMsg:  "auto_refresh_interval must be at least 30s",
+		},
+		"remapped keybinding": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				IssuePattern:         `([A-Z]+-\d+)`,
+				Keybindings:          map[string][]string{"merge": {"M"}, "refresh": {"ctrl+r", "m"}},
+			},
+			wantErr: false,
+		},
+		"conflicting keybindings": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				IssuePattern:         `([A-Z]+-\d+)`,
+				Keybindings:          map[string][]string{"refresh": {"t"}},
+			},
+			wantErr: true,
+			errMsg:  `keybindings: "t" is bound to both refresh and transition`,
+		},
+		"unknown keybinding action": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				IssuePattern:         `([A-Z]+-\d+)`,
+				Keybindings:          map[string][]string{"launch": {"l"}},
+			},
+			wantErr: true,
+			errMsg:  "keybindings has an unknown action: launch",
+		},
+		"keybinding without keys": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				IssuePattern:         `([A-Z]+-\d+)`,
+				Keybindings:          map[string][]string{"quit": {}},
+			},
+			wantErr: true,
+			errMsg:  "keybindings.quit needs at least one key",
+		},
+		"zero check threshold": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				IssuePattern:         `([A-Z]+-\d+)`,
+				CheckMaxReadyForQA:   intPtr(0),
+			},
+			wantErr: false,
+		},
+		"negative check threshold": {
+			cfg: Config{
+				AtlassianURL:         "https://test.atlassian.net",
+				AtlassianEmail:       "test@example.com",
+				AtlassianToken:       "token",
+				AtlassianProjectKeys: []string{"PROJ"},
+				GitHubToken:          "gh-token",
+				GitHubUsername:       "user",
+				GitHubRepos:          []string{"owner/repo"},
+				IssuePattern:         `([A-Z]+-\d+)`,
+				CheckMaxNeedReview:   intPtr(-1),
+			},
+			wantErr: true,
+			errMsg:  "check_max_need_review must not be negative",
+		},
+		"done threshold beyond the lookback": {
+			cfg: Config{
+				AtlassianURL:              "https://test.atlassian.net",
+				AtlassianEmail:            "test@example.com",
+				AtlassianToken:            "token",
+				AtlassianProjectKeys:      []string{"PROJ"},
+				GitHubToken:               "gh-token",
+				GitHubUsername:            "user",
+				GitHubRepos:               []string{"owner/repo"},
+				IssuePattern:              `([A-Z]+-\d+)`,
+				CheckMaxDoneNotMergedDays: intPtr(20),
+			},
+			wantErr: false,
+		},
 		"missing issue pattern": {
 			cfg: Config{
 				AtlassianURL:         "https://test.atlassian.net",
@@ -194,3 +688,7 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func intPtr(v int) *int {
+	return &v
+}
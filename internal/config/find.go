@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// EnvConfigPath points to the config file when the -config flag isn't given.
+const EnvConfigPath = "WORKFLOW_MONITOR_CONFIG"
+
+var configFileNames = []string{"config.yml", "config.yaml"}
+
+// Find returns the config file to load: the given path if any, then the one in
+// $WORKFLOW_MONITOR_CONFIG, then the first existing one among SearchPaths.
+func Find(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+
+	if path := os.Getenv(EnvConfigPath); path != "" {
+		return path, nil
+	}
+
+	candidates := SearchPaths()
+	for _, candidate := range candidates {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+
+	return "", fmt.Errorf("no config file found, use -config or create one of: %s", strings.Join(candidates, ", "))
+}
+
+// SearchPaths lists where config files are looked for, in order: the current
+// directory, $XDG_CONFIG_HOME/workflow-monitor and ~/.config/workflow-monitor.
+func SearchPaths() []string {
+	dirs := []string{"."}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		dirs = append(dirs, filepath.Join(xdg, "workflow-monitor"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dir := filepath.Join(home, ".config", "workflow-monitor")
+		// Already there when XDG_CONFIG_HOME is set to its default
+		if !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	var paths []string
+	for _, dir := range dirs {
+		for _, name := range configFileNames {
+			paths = append(paths, filepath.Join(dir, name))
+		}
+	}
+
+	return paths
+}
+
+// DefaultPath is where new config files are created: in $XDG_CONFIG_HOME/workflow-monitor,
+// or ~/.config/workflow-monitor when it isn't set.
+func DefaultPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(dir, "workflow-monitor", configFileNames[0]), nil
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	tests := map[string]struct {
+		path    string
+		env     string
+		files   []string // Relative to a temporary directory, also used as the working directory
+		want    string   // Relative to the same directory
+		wantErr bool
+	}{
+		"explicit path wins": {
+			path:  "custom.yml",
+			env:   "env.yml",
+			files: []string{"config.yml"},
+			want:  "custom.yml",
+		},
+		"environment variable": {
+			env:   "env.yml",
+			files: []string{"config.yml"},
+			want:  "env.yml",
+		},
+		"current directory": {
+			files: []string{"config.yml", "xdg/workflow-monitor/config.yml"},
+			want:  "config.yml",
+		},
+		"yaml extension": {
+			files: []string{"config.yaml"},
+			want:  "config.yaml",
+		},
+		"XDG config directory": {
+			files: []string{"xdg/workflow-monitor/config.yml", "home/.config/workflow-monitor/config.yml"},
+			want:  "xdg/workflow-monitor/config.yml",
+		},
+		"home config directory": {
+			files: []string{"home/.config/workflow-monitor/config.yml"},
+			want:  "home/.config/workflow-monitor/config.yml",
+		},
+		"not found": {
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, file := range tt.files {
+				path := filepath.Join(dir, file)
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, nil, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			t.Chdir(dir)
+			t.Setenv("HOME", filepath.Join(dir, "home"))
+			t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "xdg"))
+			t.Setenv(EnvConfigPath, tt.env)
+
+			got, err := Find(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Find() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			// Found paths are relative to the working directory or absolute
+			if !filepath.IsAbs(got) {
+				got = filepath.Join(dir, got)
+			}
+			if want := filepath.Join(dir, tt.want); got != want {
+				t.Errorf("Find() = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	tests := map[string]struct {
+		xdg  string
+		want string
+	}{
+		"XDG config directory": {
+			xdg:  "/xdg",
+			want: "/xdg/workflow-monitor/config.yml",
+		},
+		"home config directory": {
+			want: "/home/me/.config/workflow-monitor/config.yml",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("HOME", "/home/me")
+			t.Setenv("XDG_CONFIG_HOME", tt.xdg)
+
+			got, err := DefaultPath()
+			if err != nil {
+				t.Fatalf("DefaultPath() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DefaultPath() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
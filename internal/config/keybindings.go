@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Actions of the TUI list views that can be bound to keys in the keybindings section.
+const (
+	ActionQuit         = "quit"
+	ActionSwitchView   = "switch_view"
+	ActionUp           = "up"
+	ActionDown         = "down"
+	ActionPageUp       = "page_up"
+	ActionPageDown     = "page_down"
+	ActionTop          = "top"
+	ActionBottom       = "bottom"
+	ActionOpen         = "open"
+	ActionDetails      = "details"
+	ActionFilter       = "filter"
+	ActionClearFilter  = "clear_filter"
+	ActionTransition   = "transition"
+	ActionMerge        = "merge"
+	ActionReview       = "review"
+	ActionRefresh      = "refresh"
+	ActionErrorDetails = "error_details"
+	ActionDismissError = "dismiss_error"
+	ActionHelp         = "help"
+)
+
+// DefaultKeybindings are the keys of each action, replaced action by action by the
+// keybindings section.
+var DefaultKeybindings = map[string][]string{
+	ActionQuit:         {"q", "ctrl+c"},
+	ActionSwitchView:   {"tab"},
+	ActionUp:           {"up", "k"},
+	ActionDown:         {"down", "j"},
+	ActionPageUp:       {"pgup"},
+	ActionPageDown:     {"pgdown"},
+	ActionTop:          {"home"},
+	ActionBottom:       {"end"},
+	ActionOpen:         {"enter"},
+	ActionDetails:      {"d"},
+	ActionFilter:       {"/"},
+	ActionClearFilter:  {"esc"},
+	ActionTransition:   {"t"},
+	ActionMerge:        {"m"},
+	ActionReview:       {"a"},
+	ActionRefresh:      {"r"},
+	ActionErrorDetails: {"e"},
+	ActionDismissError: {"x"},
+	ActionHelp:         {"?"},
+}
+
+// KeyBindings returns the keys of every action, with the configured ones taking
+// precedence over the defaults.
+func (cfg *Config) KeyBindings() map[string][]string {
+	bindings := make(map[string][]string, len(DefaultKeybindings))
+	for action, keys := range DefaultKeybindings {
+		bindings[action] = keys
+	}
+	for action, keys := range cfg.Keybindings {
+		bindings[action] = keys
+	}
+
+	return bindings
+}
+
+func (cfg *Config) validateKeybindings() error {
+	for action, keys := range cfg.Keybindings {
+		if _, ok := DefaultKeybindings[action]; !ok {
+			return fmt.Errorf("keybindings has an unknown action: %s", action)
+		}
+
+		if len(keys) == 0 {
+			return fmt.Errorf("keybindings.%s needs at least one key", action)
+		}
+	}
+
+	bindings := cfg.KeyBindings()
+	actions := make([]string, 0, len(bindings))
+	for action := range bindings {
+		actions = append(actions, action)
+	}
+	// Sorted so the same conflict is always reported
+	slices.Sort(actions)
+
+	boundTo := make(map[string]string)
+	for _, action := range actions {
+		for _, key := range bindings[action] {
+			if other, ok := boundTo[key]; ok && other != action {
+				return fmt.Errorf("keybindings: %q is bound to both %s and %s", key, other, action)
+			}
+			boundTo[key] = action
+		}
+	}
+
+	return nil
+}
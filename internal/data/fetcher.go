@@ -2,7 +2,9 @@ package data
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/andygrunwald/go-jira"
@@ -18,6 +20,16 @@ type Fetcher struct {
 	ghClient        *gh.Client
 	matcher         *analyzer.Matcher
 	cfg             *config.Config
+
+	// Data of the last successful FetchAll, kept to regenerate insights after actions
+	mu                 sync.Mutex
+	myIssues           []jira.Issue
+	issueIDToOpenPRs   map[string][]gh.PullRequest
+	prsNeedingMyReview []gh.PullRequest
+
+	// Incremented by actions updating the data above, so that a FetchAll started
+	// before them doesn't overwrite it with what it read before the action
+	generation int
 }
 
 func NewFetcher(cfg *config.Config) (*Fetcher, error) {
@@ -27,7 +39,10 @@ func NewFetcher(cfg *config.Config) (*Fetcher, error) {
 	}
 	debug.Printf("Atlassian client created successfully")
 
-	ghClient := gh.NewClient(cfg)
+	ghClient, err := gh.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub client: %v", err)
+	}
 	debug.Printf("GitHub client created successfully")
 
 	matcher := analyzer.NewMatcher(cfg.IssuePattern)
@@ -41,6 +56,10 @@ func NewFetcher(cfg *config.Config) (*Fetcher, error) {
 }
 
 func (f *Fetcher) FetchAll(ctx context.Context) (*analyzer.Insights, error) {
+	f.mu.Lock()
+	generation := f.generation
+	f.mu.Unlock()
+
 	var myIssues []jira.Issue
 	var openPRs, prsNeedingMyReview []gh.PullRequest
 	var myIssuesErr, openPRsErr, prsNeedingMyReviewErr error
@@ -51,7 +70,7 @@ func (f *Fetcher) FetchAll(ctx context.Context) (*analyzer.Insights, error) {
 	go func() {
 		defer wg.Done()
 
-		myIssues, myIssuesErr = f.atlassianClient.FetchMyIssuesInReviewOrDone()
+		myIssues, myIssuesErr = f.atlassianClient.FetchMyIssuesInReviewOrDone(ctx)
 		debug.Printf("Fetched %d issues of mine", len(myIssues))
 		debug.Printf("My issues: %+v", myIssues)
 	}()
@@ -74,18 +93,124 @@ func (f *Fetcher) FetchAll(ctx context.Context) (*analyzer.Insights, error) {
 
 	wg.Wait()
 
+	// Wrapped and joined so the TUI can show every failure and its causes
+	var errs []error
 	if myIssuesErr != nil {
-		return nil, fmt.Errorf("failed to fetch my issues: %v", myIssuesErr)
+		errs = append(errs, fmt.Errorf("failed to fetch my issues: %w", myIssuesErr))
 	}
 	if openPRsErr != nil {
-		return nil, fmt.Errorf("failed to fetch open PRs: %v", openPRsErr)
+		errs = append(errs, fmt.Errorf("failed to fetch open PRs: %w", openPRsErr))
 	}
 	if prsNeedingMyReviewErr != nil {
-		return nil, fmt.Errorf("failed to fetch PRs needing my review: %v", prsNeedingMyReviewErr)
+		errs = append(errs, fmt.Errorf("failed to fetch PRs needing my review: %w", prsNeedingMyReviewErr))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
 	}
 
 	issueIDToOpenPRs := f.matcher.IssueIDToPRs(openPRs)
 	debug.Printf("issueIDToOpenPRs: %+v", issueIDToOpenPRs)
 
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.generation != generation {
+		debug.Printf("An action completed during the fetch, keeping the data it updated")
+		return analyzer.GenerateInsights(myIssues, issueIDToOpenPRs, prsNeedingMyReview, f.cfg)
+	}
+
+	f.myIssues = myIssues
+	f.issueIDToOpenPRs = issueIDToOpenPRs
+	f.prsNeedingMyReview = prsNeedingMyReview
+
 	return analyzer.GenerateInsights(myIssues, issueIDToOpenPRs, prsNeedingMyReview, f.cfg)
 }
+
+// SetIssueLookbackDays changes how many days back FetchAll searches the updated
+// Jira tickets.
+func (f *Fetcher) SetIssueLookbackDays(days int) {
+	f.atlassianClient.SetLookbackDays(days)
+}
+
+func (f *Fetcher) FetchTransitions(ctx context.Context, issueID string) ([]atlassian.Transition, error) {
+	return f.atlassianClient.FetchTransitions(ctx, issueID)
+}
+
+// TransitionIssue moves the issue through the given transition and returns the
+// insights regenerated with its new status, without refetching everything else.
+func (f *Fetcher) TransitionIssue(ctx context.Context, issueID, transitionID string) (*analyzer.Insights, error) {
+	if err := f.atlassianClient.TransitionIssue(ctx, issueID, transitionID); err != nil {
+		return nil, err
+	}
+
+	issue, err := f.atlassianClient.FetchIssue(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	debug.Printf("Transitioned %s, refreshing its insights", issueID)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := range f.myIssues {
+		if f.myIssues[i].Key == issueID {
+			f.myIssues[i] = *issue
+		}
+	}
+	f.generation++
+
+	return analyzer.GenerateInsights(f.myIssues, f.issueIDToOpenPRs, f.prsNeedingMyReview, f.cfg)
+}
+
+func (f *Fetcher) FetchIssueDetails(ctx context.Context, issueID string) (*atlassian.IssueDetails, error) {
+	return f.atlassianClient.FetchIssueDetails(ctx, issueID)
+}
+
+func (f *Fetcher) FetchPRDetails(ctx context.Context, pr gh.PullRequest) (*gh.PRDetails, error) {
+	return f.ghClient.FetchPRDetails(ctx, pr)
+}
+
+func (f *Fetcher) GitHubRateLimit() gh.RateLimit {
+	return f.ghClient.RateLimit()
+}
+
+func (f *Fetcher) CheckGitHubRateLimitBudget() error {
+	return f.ghClient.CheckRateLimitBudget()
+}
+
+// MergePullRequest merges the PR and returns the insights regenerated without it.
+func (f *Fetcher) MergePullRequest(ctx context.Context, pr gh.PullRequest) (*analyzer.Insights, error) {
+	if err := f.ghClient.MergePullRequest(ctx, pr); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for issueID, prs := range f.issueIDToOpenPRs {
+		f.issueIDToOpenPRs[issueID] = slices.DeleteFunc(prs, func(openPR gh.PullRequest) bool {
+			return openPR.URL == pr.URL
+		})
+	}
+	f.generation++
+
+	return analyzer.GenerateInsights(f.myIssues, f.issueIDToOpenPRs, f.prsNeedingMyReview, f.cfg)
+}
+
+// SubmitReview submits the review and returns the insights regenerated without
+// the PR in the ones needing my review.
+func (f *Fetcher) SubmitReview(ctx context.Context, pr gh.PullRequest, event, body string) (*analyzer.Insights, error) {
+	if err := f.ghClient.SubmitReview(ctx, pr, event, body); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.prsNeedingMyReview = slices.DeleteFunc(f.prsNeedingMyReview, func(reviewPR gh.PullRequest) bool {
+		return reviewPR.URL == pr.URL
+	})
+	f.generation++
+
+	return analyzer.GenerateInsights(f.myIssues, f.issueIDToOpenPRs, f.prsNeedingMyReview, f.cfg)
+}
@@ -0,0 +1,146 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pippokairos/workflow-monitor/internal/analyzer"
+	"github.com/pippokairos/workflow-monitor/internal/config"
+	"github.com/pippokairos/workflow-monitor/internal/gh"
+)
+
+// newTestFetcher returns a fetcher whose Jira and GitHub clients both use a server
+// returning PROJ-1 done with its PR #1 open and PR #2 needing my review. While
+// block is set, the Jira search signals started then waits for release.
+func newTestFetcher(t *testing.T, block *atomic.Bool, started, release chan struct{}) *Fetcher {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/3/search/jql":
+			if block.Load() {
+				started <- struct{}{}
+				<-release
+			}
+			fmt.Fprint(w, `{"issues":[{"key":"PROJ-1","fields":{"status":{"name":"Done"}}}],"isLast":true}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue/PROJ-1/transitions":
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/rest/api/2/issue/PROJ-1":
+			fmt.Fprint(w, `{"key":"PROJ-1","fields":{"status":{"name":"Code Review"}}}`)
+		case r.URL.Path == "/api/v3/repos/owner/repo/pulls":
+			fmt.Fprint(w, `[{"number":1,"html_url":"https://github.com/owner/repo/pull/1","user":{"login":"testuser"},"head":{"ref":"PROJ-1-fix"}}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/repos/owner/repo/pulls/1":
+			fmt.Fprint(w, `{"number":1,"state":"open","mergeable":true,"mergeable_state":"clean","head":{"sha":"abc"}}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v3/repos/owner/repo/pulls/1/merge":
+			fmt.Fprint(w, `{"merged":true}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v3/repos/owner/repo/pulls/2/reviews":
+			fmt.Fprint(w, `{}`)
+		case r.URL.Path == "/api/v3/repos/owner/repo/pulls/1/reviews":
+			fmt.Fprint(w, `[]`)
+		case r.URL.Path == "/api/v3/search/issues":
+			fmt.Fprint(w, `{"items":[{"number":2,"html_url":"https://github.com/owner/repo/pull/2","repository_url":"https://api.github.com/repos/owner/repo","user":{"login":"alice"}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	fetcher, err := NewFetcher(&config.Config{
+		AtlassianURL:          server.URL,
+		AtlassianEmail:        "test@example.com",
+		AtlassianToken:        "token",
+		AtlassianSearchAPI:    config.AtlassianSearchAPICloud,
+		AtlassianStatusReview: "Code Review",
+		AtlassianStatusDone:   "Done",
+		GitHubToken:           "gh-token",
+		GitHubUsername:        "testuser",
+		GitHubRepos:           []string{"owner/repo"},
+		GitHubBaseURL:         server.URL,
+		IssuePattern:          `PROJ-\d+`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fetcher
+}
+
+func TestFetchAllDuringAction(t *testing.T) {
+	tests := map[string]struct {
+		action            func(ctx context.Context, f *Fetcher) (*analyzer.Insights, error)
+		wantDoneNotMerged int
+		wantNeedReview    int
+	}{
+		"transition": {
+			action: func(ctx context.Context, f *Fetcher) (*analyzer.Insights, error) {
+				return f.TransitionIssue(ctx, "PROJ-1", "21")
+			},
+			wantDoneNotMerged: 0,
+			wantNeedReview:    1,
+		},
+		"merge": {
+			action: func(ctx context.Context, f *Fetcher) (*analyzer.Insights, error) {
+				return f.MergePullRequest(ctx, gh.PullRequest{URL: "https://github.com/owner/repo/pull/1", Number: 1, Repo: "owner/repo"})
+			},
+			wantDoneNotMerged: 0,
+			wantNeedReview:    1,
+		},
+		"review": {
+			action: func(ctx context.Context, f *Fetcher) (*analyzer.Insights, error) {
+				return f.SubmitReview(ctx, gh.PullRequest{URL: "https://github.com/owner/repo/pull/2", Number: 2, Repo: "owner/repo"}, gh.ReviewApprove, "")
+			},
+			wantDoneNotMerged: 1,
+			wantNeedReview:    0,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			var block atomic.Bool
+			started, release := make(chan struct{}), make(chan struct{})
+			f := newTestFetcher(t, &block, started, release)
+
+			insights, err := f.FetchAll(ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(insights.DoneNotMergedPRs) != 1 || len(insights.NeedReviewPRs) != 1 {
+				t.Fatalf("Expected a PR done not merged and one needing review, got %+v", insights)
+			}
+
+			// The fetch reads the data from before the action, which completes first
+			block.Store(true)
+			fetched := make(chan error)
+			go func() {
+				_, err := f.FetchAll(ctx)
+				fetched <- err
+			}()
+			<-started
+
+			block.Store(false)
+			if _, err := tt.action(ctx, f); err != nil {
+				t.Fatalf("Unexpected action error: %v", err)
+			}
+			close(release)
+			if err := <-fetched; err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			f.mu.Lock()
+			insights, err = analyzer.GenerateInsights(f.myIssues, f.issueIDToOpenPRs, f.prsNeedingMyReview, f.cfg)
+			f.mu.Unlock()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(insights.DoneNotMergedPRs) != tt.wantDoneNotMerged || len(insights.NeedReviewPRs) != tt.wantNeedReview {
+				t.Errorf("Expected the data updated by the action to be kept, got %d done not merged and %d needing review",
+					len(insights.DoneNotMergedPRs), len(insights.NeedReviewPRs))
+			}
+		})
+	}
+}
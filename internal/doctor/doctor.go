@@ -0,0 +1,269 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pippokairos/workflow-monitor/internal/atlassian"
+	"github.com/pippokairos/workflow-monitor/internal/config"
+	"github.com/pippokairos/workflow-monitor/internal/gh"
+)
+
+const (
+	StatusPass = "PASS"
+	StatusWarn = "WARN"
+	StatusFail = "FAIL"
+	StatusSkip = "SKIP"
+)
+
+// Issue number used in the sample branch names issue_pattern must match.
+const sampleIssueNumber = 123
+
+type Result struct {
+	Check  string
+	Status string
+	Detail string
+}
+
+type jiraClient interface {
+	Myself(ctx context.Context) (string, error)
+	ProjectStatuses(ctx context.Context, projectKey string) ([]string, error)
+}
+
+type githubClient interface {
+	TokenOwner(ctx context.Context) (string, []string, error)
+	RepoAccess(ctx context.Context, repo string) (*gh.RepoAccess, error)
+}
+
+// Run checks the credentials, repos, workflow statuses and issue pattern of the config.
+func Run(ctx context.Context, cfg *config.Config) []Result {
+	var results []Result
+
+	// Left nil when the client can't be created, skipping its checks
+	var jira jiraClient
+	if client, err := atlassian.NewClient(cfg); err != nil {
+		results = append(results, Result{"Jira client", StatusFail, err.Error()})
+	} else {
+		jira = client
+	}
+
+	var github githubClient
+	if client, err := gh.NewClient(cfg); err != nil {
+		results = append(results, Result{"GitHub client", StatusFail, err.Error()})
+	} else {
+		github = client
+	}
+
+	return append(results, run(ctx, cfg, jira, github)...)
+}
+
+func run(ctx context.Context, cfg *config.Config, jira jiraClient, github githubClient) []Result {
+	var results []Result
+	if jira != nil {
+		results = append(results, checkJira(ctx, cfg, jira)...)
+	}
+
+	var branches []string
+	if github != nil {
+		results = append(results, checkGitHubUser(ctx, cfg, github))
+		for _, repo := range cfg.GitHubRepos {
+			result, access := checkRepo(ctx, repo, github)
+			results = append(results, result)
+			if access != nil {
+				branches = append(branches, access.Branches...)
+			}
+		}
+	}
+
+	return append(results, checkIssuePattern(cfg, branches))
+}
+
+func checkJira(ctx context.Context, cfg *config.Config, jira jiraClient) []Result {
+	name, err := jira.Myself(ctx)
+	if err != nil {
+		return []Result{
+			{"Jira authentication", StatusFail, err.Error()},
+			{"Jira statuses", StatusSkip, "Jira authentication failed"},
+		}
+	}
+
+	results := []Result{{"Jira authentication", StatusPass, "authenticated as " + name}}
+	if len(cfg.AtlassianProjectKeys) == 0 {
+		return append(results, Result{"Jira statuses", StatusSkip, "no atlassian_project_keys configured"})
+	}
+
+	wanted := []string{cfg.AtlassianStatusReview, cfg.AtlassianStatusDone}
+	for _, key := range cfg.AtlassianProjectKeys {
+		check := "Jira statuses of " + key
+
+		statuses, err := jira.ProjectStatuses(ctx, key)
+		if err != nil {
+			results = append(results, Result{check, StatusFail, err.Error()})
+			continue
+		}
+
+		var missing []string
+		for _, status := range wanted {
+			// Jira compares status names case-insensitively in JQL
+			if status != "" && !slices.ContainsFunc(statuses, func(s string) bool { return strings.EqualFold(s, status) }) {
+				missing = append(missing, fmt.Sprintf("%q", status))
+			}
+		}
+
+		if len(missing) > 0 {
+			results = append(results, Result{check, StatusFail, fmt.Sprintf("%s not found (available: %s)", strings.Join(missing, " and "), strings.Join(statuses, ", "))})
+		} else {
+			results = append(results, Result{check, StatusPass, fmt.Sprintf("%q and %q exist", cfg.AtlassianStatusReview, cfg.AtlassianStatusDone)})
+		}
+	}
+
+	return results
+}
+
+func checkGitHubUser(ctx context.Context, cfg *config.Config, github githubClient) Result {
+	const check = "GitHub authentication"
+
+	// Installation tokens don't belong to a user
+	if cfg.UsesGitHubApp() {
+		return Result{check, StatusSkip, "authenticated as a GitHub App, github_username can't be verified"}
+	}
+
+	login, scopes, err := github.TokenOwner(ctx)
+	if err != nil {
+		return Result{check, StatusFail, err.Error()}
+	}
+
+	if !strings.EqualFold(login, cfg.GitHubUsername) {
+		return Result{check, StatusFail, fmt.Sprintf("github_token belongs to %s, not to github_username %s", login, cfg.GitHubUsername)}
+	}
+
+	detail := "authenticated as " + login
+
+	// Fine-grained tokens don't report scopes, their permissions can't be checked
+	if len(scopes) == 0 {
+		return Result{check, StatusPass, detail}
+	}
+	detail += " (scopes: " + strings.Join(scopes, ", ") + ")"
+
+	// Without repo, private repos can't be read nor PRs merged or reviewed
+	status := StatusPass
+	var missing []string
+	if !slices.Contains(scopes, "repo") {
+		status = StatusFail
+		missing = append(missing, "repo")
+	}
+
+	// Organizations may hide their repos and teams from tokens without read:org
+	grantsReadOrg := func(scope string) bool { return scope == "read:org" || scope == "write:org" || scope == "admin:org" }
+	if hasOrgRepos(cfg) && !slices.ContainsFunc(scopes, grantsReadOrg) {
+		if status == StatusPass {
+			status = StatusWarn
+		}
+		missing = append(missing, "read:org")
+	}
+
+	if len(missing) > 0 {
+		detail += ", missing " + strings.Join(missing, " and ")
+	}
+
+	return Result{check, status, detail}
+}
+
+// hasOrgRepos tells whether some repos belong to someone else than github_username,
+// most likely an organization.
+func hasOrgRepos(cfg *config.Config) bool {
+	return slices.ContainsFunc(cfg.GitHubRepos, func(repo string) bool {
+		owner, _, _ := strings.Cut(repo, "/")
+		return !strings.EqualFold(owner, cfg.GitHubUsername)
+	})
+}
+
+func checkRepo(ctx context.Context, repo string, github githubClient) (Result, *gh.RepoAccess) {
+	check := "GitHub repo " + repo
+
+	access, err := github.RepoAccess(ctx, repo)
+	if err != nil {
+		return Result{check, StatusFail, err.Error()}, nil
+	}
+
+	if access.ReadOnly {
+		return Result{check, StatusWarn, "read-only, PRs can't be merged from the TUI"}, access
+	}
+
+	return Result{check, StatusPass, "reachable"}, access
+}
+
+// checkIssuePattern matches issue_pattern against a typical branch name for each
+// project, then reports how many of the given real branches it matches.
+func checkIssuePattern(cfg *config.Config, branches []string) Result {
+	const check = "Issue pattern"
+
+	pattern, err := regexp.Compile(cfg.IssuePattern)
+	if err != nil {
+		return Result{check, StatusFail, fmt.Sprintf("issue_pattern is invalid: %v", err)}
+	}
+
+	keys := cfg.AtlassianProjectKeys
+	if len(keys) == 0 {
+		keys = []string{"PROJ"}
+	}
+
+	for _, key := range keys {
+		issueID := fmt.Sprintf("%s-%d", key, sampleIssueNumber)
+		branch := fmt.Sprintf("feature/%s-short-description", issueID)
+		if got := pattern.FindString(branch); got != issueID {
+			return Result{check, StatusFail, fmt.Sprintf("expected %s in branch %s, got %q", issueID, branch, got)}
+		}
+	}
+
+	if len(branches) == 0 {
+		return Result{check, StatusPass, "matches sample branch names"}
+	}
+
+	var matched int
+	for _, branch := range branches {
+		if pattern.MatchString(branch) {
+			matched++
+		}
+	}
+
+	detail := fmt.Sprintf("matches sample branch names and %d of %d open PR branches", matched, len(branches))
+	if matched == 0 {
+		return Result{check, StatusWarn, detail}
+	}
+
+	return Result{check, StatusPass, detail}
+}
+
+func Failed(results []Result) bool {
+	return slices.ContainsFunc(results, func(r Result) bool { return r.Status == StatusFail })
+}
+
+// Write prints the results as a table, followed by a summary line.
+func Write(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tCHECK\tDETAIL")
+	var failed int
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Status, r.Check, r.Detail)
+		if r.Status == StatusFail {
+			failed++
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	summary := "\nAll checks passed\n"
+	if failed > 0 {
+		summary = fmt.Sprintf("\n%d of %d checks failed\n", failed, len(results))
+	}
+
+	_, err := io.WriteString(w, summary)
+	return err
+}
@@ -0,0 +1,313 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pippokairos/workflow-monitor/internal/config"
+	"github.com/pippokairos/workflow-monitor/internal/gh"
+)
+
+type fakeJira struct {
+	myselfErr error
+	statuses  map[string][]string
+}
+
+func (f *fakeJira) Myself(ctx context.Context) (string, error) {
+	return "Jane Doe", f.myselfErr
+}
+
+func (f *fakeJira) ProjectStatuses(ctx context.Context, projectKey string) ([]string, error) {
+	statuses, ok := f.statuses[projectKey]
+	if !ok {
+		return nil, errors.New("project " + projectKey + " not found or not visible")
+	}
+	return statuses, nil
+}
+
+type fakeGitHub struct {
+	login  string
+	scopes []string
+	repos  map[string]*gh.RepoAccess
+}
+
+func (f *fakeGitHub) TokenOwner(ctx context.Context) (string, []string, error) {
+	return f.login, f.scopes, nil
+}
+
+func (f *fakeGitHub) RepoAccess(ctx context.Context, repo string) (*gh.RepoAccess, error) {
+	access, ok := f.repos[repo]
+	if !ok {
+		return nil, errors.New(repo + " not found or not accessible with these credentials")
+	}
+	return access, nil
+}
+
+func newTestConfig() *config.Config {
+	return &config.Config{
+		AtlassianStatusReview: "Code Review",
+		AtlassianStatusDone:   "Done",
+		AtlassianProjectKeys:  []string{"PROJ"},
+		GitHubUsername:        "testuser",
+		GitHubRepos:           []string{"owner/repo"},
+		IssuePattern:          `([A-Z]+-\d+)`,
+	}
+}
+
+func TestRun(t *testing.T) {
+	healthyJira := func() *fakeJira {
+		return &fakeJira{statuses: map[string][]string{"PROJ": {"To Do", "Code Review", "Done"}}}
+	}
+	healthyGitHub := func() *fakeGitHub {
+		return &fakeGitHub{login: "testuser", scopes: []string{"repo", "read:org"}, repos: map[string]*gh.RepoAccess{
+			"owner/repo": {Branches: []string{"feature/PROJ-1-login"}},
+		}}
+	}
+
+	tests := map[string]struct {
+		configure func(cfg *config.Config, jira *fakeJira, github *fakeGitHub)
+		want      map[string]string // Check name to status, other checks must pass
+	}{
+		"everything fine": {},
+		"Jira authentication fails": {
+			configure: func(cfg *config.Config, jira *fakeJira, github *fakeGitHub) {
+				jira.myselfErr = errors.New("401 Unauthorized")
+			},
+			want: map[string]string{"Jira authentication": StatusFail, "Jira statuses": StatusSkip},
+		},
+		"status missing from the workflow": {
+			configure: func(cfg *config.Config, jira *fakeJira, github *fakeGitHub) {
+				cfg.AtlassianStatusReview = "In Review"
+			},
+			want: map[string]string{"Jira statuses of PROJ": StatusFail},
+		},
+		"unknown project": {
+			configure: func(cfg *config.Config, jira *fakeJira, github *fakeGitHub) {
+				cfg.AtlassianProjectKeys = []string{"PROJ", "OTHER"}
+			},
+			want: map[string]string{"Jira statuses of OTHER": StatusFail},
+		},
+		"token of another user": {
+			configure: func(cfg *config.Config, jira *fakeJira, github *fakeGitHub) {
+				github.login = "someoneelse"
+			},
+			want: map[string]string{"GitHub authentication": StatusFail},
+		},
+		"token without repo scope": {
+			configure: func(cfg *config.Config, jira *fakeJira, github *fakeGitHub) {
+				github.scopes = []string{"read:org", "gist"}
+			},
+			want: map[string]string{"GitHub authentication": StatusFail},
+		},
+		"token without read:org scope for org repos": {
+			configure: func(cfg *config.Config, jira *fakeJira, github *fakeGitHub) {
+				github.scopes = []string{"repo"}
+			},
+			want: map[string]string{"GitHub authentication": StatusWarn},
+		},
+		"token without read:org scope for own repos": {
+			configure: func(cfg *config.Config, jira *fakeJira, github *fakeGitHub) {
+				cfg.GitHubRepos = []string{"TestUser/repo"}
+				github.scopes = []string{"repo"}
+				github.repos["TestUser/repo"] = &gh.RepoAccess{Branches: []string{"feature/PROJ-1-login"}}
+			},
+		},
+		"token with a scope implying read:org": {
+			configure: func(cfg *config.Config, jira *fakeJira, github *fakeGitHub) {
+				github.scopes = []string{"repo", "admin:org"}
+			},
+		},
+		"fine-grained token": {
+			configure: func(cfg *config.Config, jira *fakeJira, github *fakeGitHub) {
+				github.scopes = nil
+			},
+		},
+		"GitHub App": {
+			configure: func(cfg *config.Config, jira *fakeJira, github *fakeGitHub) {
+				cfg.GitHubAppID = 1
+			},
+			want: map[string]string{"GitHub authentication": StatusSkip},
+		},
+		"unreachable repo": {
+			configure: func(cfg *config.Config, jira *fakeJira, github *fakeGitHub) {
+				cfg.GitHubRepos = []string{"owner/repo", "owner/private"}
+			},
+			want: map[string]string{"GitHub repo owner/private": StatusFail},
+		},
+		"read-only repo": {
+			configure: func(cfg *config.Config, jira *fakeJira, github *fakeGitHub) {
+				github.repos["owner/repo"].ReadOnly = true
+			},
+			want: map[string]string{"GitHub repo owner/repo": StatusWarn},
+		},
+		"invalid issue pattern": {
+			configure: func(cfg *config.Config, jira *fakeJira, github *fakeGitHub) {
+				cfg.IssuePattern = `([A-Z]+`
+			},
+			want: map[string]string{"Issue pattern": StatusFail},
+		},
+		"issue pattern not matching the project keys": {
+			configure: func(cfg *config.Config, jira *fakeJira, github *fakeGitHub) {
+				cfg.IssuePattern = `(ABC-\d+)`
+			},
+			want: map[string]string{"Issue pattern": StatusFail},
+		},
+		"issue pattern matching no open PR branch": {
+			configure: func(cfg *config.Config, jira *fakeJira, github *fakeGitHub) {
+				github.repos["owner/repo"].Branches = []string{"fix-typo"}
+			},
+			want: map[string]string{"Issue pattern": StatusWarn},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg, jira, github := newTestConfig(), healthyJira(), healthyGitHub()
+			if tt.configure != nil {
+				tt.configure(cfg, jira, github)
+			}
+
+			results := run(context.Background(), cfg, jira, github)
+
+			seen := make(map[string]bool)
+			for _, r := range results {
+				seen[r.Check] = true
+
+				want, ok := tt.want[r.Check]
+				if !ok {
+					want = StatusPass
+				}
+				if r.Status != want {
+					t.Errorf("Expected %s to be %s, got %s (%s)", r.Check, want, r.Status, r.Detail)
+				}
+			}
+
+			for check := range tt.want {
+				if !seen[check] {
+					t.Errorf("Expected a %s result, got %+v", check, results)
+				}
+			}
+
+			if wantFailed := slices.Contains(slices.Collect(maps.Values(tt.want)), StatusFail); Failed(results) != wantFailed {
+				t.Errorf("Expected Failed() = %v", wantFailed)
+			}
+		})
+	}
+}
+
+func TestCheckGitHubUserScopes(t *testing.T) {
+	tests := map[string]struct {
+		scopes     string
+		wantStatus string
+		wantDetail string
+	}{
+		"repo and read:org": {
+			scopes:     "read:org, repo",
+			wantStatus: StatusPass,
+			wantDetail: "authenticated as testuser (scopes: read:org, repo)",
+		},
+		"without repo": {
+			scopes:     "read:org, workflow",
+			wantStatus: StatusFail,
+			wantDetail: "authenticated as testuser (scopes: read:org, workflow), missing repo",
+		},
+		"without any needed scope": {
+			scopes:     "gist",
+			wantStatus: StatusFail,
+			wantDetail: "authenticated as testuser (scopes: gist), missing repo and read:org",
+		},
+		"without read:org": {
+			scopes:     "repo",
+			wantStatus: StatusWarn,
+			wantDetail: "authenticated as testuser (scopes: repo), missing read:org",
+		},
+		"scopes not reported": {
+			wantStatus: StatusPass,
+			wantDetail: "authenticated as testuser",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v3/user" {
+					http.NotFound(w, r)
+					return
+				}
+				if tt.scopes != "" {
+					w.Header().Set("X-OAuth-Scopes", tt.scopes)
+				}
+				fmt.Fprint(w, `{"login":"testuser"}`)
+			}))
+			defer server.Close()
+
+			cfg := newTestConfig()
+			cfg.GitHubToken = "gh-token"
+			cfg.GitHubBaseURL = server.URL + "/"
+			client, err := gh.NewClient(cfg)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			result := checkGitHubUser(context.Background(), cfg, client)
+			if result.Status != tt.wantStatus {
+				t.Errorf("Expected %s, got %s (%s)", tt.wantStatus, result.Status, result.Detail)
+			}
+			if result.Detail != tt.wantDetail {
+				t.Errorf("Expected detail %q, got %q", tt.wantDetail, result.Detail)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	tests := map[string]struct {
+		results     []Result
+		wantSummary string
+	}{
+		"all passed": {
+			results: []Result{
+				{"Jira authentication", StatusPass, "authenticated as Jane Doe"},
+				{"GitHub repo owner/repo", StatusWarn, "read-only, PRs can't be merged from the TUI"},
+			},
+			wantSummary: "All checks passed",
+		},
+		"some failed": {
+			results: []Result{
+				{"Jira authentication", StatusFail, "401 Unauthorized"},
+				{"Issue pattern", StatusPass, "matches sample branch names"},
+			},
+			wantSummary: "1 of 2 checks failed",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := Write(&sb, tt.results); err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+
+			lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+			if !strings.HasPrefix(lines[0], "STATUS  CHECK") {
+				t.Errorf("Expected a header, got %q", lines[0])
+			}
+			for i, r := range tt.results {
+				line := lines[i+1]
+				if !strings.HasPrefix(line, r.Status) || !strings.Contains(line, r.Check) || !strings.HasSuffix(line, r.Detail) {
+					t.Errorf("Expected a row for %+v, got %q", r, line)
+				}
+			}
+			if last := lines[len(lines)-1]; last != tt.wantSummary {
+				t.Errorf("Expected summary %q, got %q", tt.wantSummary, last)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package gh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v79/github"
+	"github.com/pippokairos/workflow-monitor/internal/debug"
+)
+
+// Number of open PRs whose branches are returned by RepoAccess.
+const sampleBranches = 10
+
+// RepoAccess describes what the credentials can do in a repo.
+type RepoAccess struct {
+	// Set only when GitHub reports the permissions and pushing isn't allowed,
+	// in which case PRs can't be merged from the TUI
+	ReadOnly bool
+	// Branches of the most recent open PRs
+	Branches []string
+}
+
+// TokenOwner returns the login of the user github_token belongs to, along with
+// its scopes (only reported for classic tokens). It isn't available to GitHub Apps.
+func (c *Client) TokenOwner(ctx context.Context) (string, []string, error) {
+	user, resp, err := c.github.Users.Get(ctx, "")
+	debug.Printf("GitHub Users Get response: %+v", resp)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to fetch the token owner: %w", err)
+	}
+
+	var scopes []string
+	if header := resp.Header.Get("X-OAuth-Scopes"); header != "" {
+		for scope := range strings.SplitSeq(header, ",") {
+			scopes = append(scopes, strings.TrimSpace(scope))
+		}
+	}
+
+	return user.GetLogin(), scopes, nil
+}
+
+// RepoAccess checks that the repo and its open PRs can be read.
+func (c *Client) RepoAccess(ctx context.Context, repoCfg string) (*RepoAccess, error) {
+	owner, repo, err := getOwnerAndRepo(repoCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	githubRepo, resp, err := c.github.Repositories.Get(ctx, owner, repo)
+	debug.Printf("GitHub Repositories Get response: %+v", resp)
+	if err != nil {
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%s not found or not accessible with these credentials", repoCfg)
+		}
+		return nil, fmt.Errorf("failed to fetch %s: %w", repoCfg, err)
+	}
+
+	options := &github.PullRequestListOptions{
+		State:       "open",
+		ListOptions: github.ListOptions{PerPage: sampleBranches},
+	}
+	prs, resp, err := c.github.PullRequests.List(ctx, owner, repo, options)
+	debug.Printf("GitHub PullRequests List response: %+v", resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the pull requests of %s: %w", repoCfg, err)
+	}
+
+	access := &RepoAccess{}
+	if githubRepo.Permissions != nil {
+		access.ReadOnly = !githubRepo.Permissions["push"]
+	}
+	for _, pr := range prs {
+		access.Branches = append(access.Branches, pr.GetHead().GetRef())
+	}
+
+	return access, nil
+}
+
+// ListRepos returns the repos the token's user can access, most recently pushed
+// first, in owner/repo format.
+func (c *Client) ListRepos(ctx context.Context) ([]string, error) {
+	options := &github.RepositoryListByAuthenticatedUserOptions{
+		Sort:        "pushed",
+		ListOptions: github.ListOptions{PerPage: c.perPage},
+	}
+
+	var repos []string
+	for {
+		page, resp, err := c.github.Repositories.ListByAuthenticatedUser(ctx, options)
+		debug.Printf("GitHub Repositories ListByAuthenticatedUser response: %+v", resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repos: %w", err)
+		}
+
+		for _, repo := range page {
+			repos = append(repos, repo.GetFullName())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+
+	return repos, nil
+}
@@ -0,0 +1,172 @@
+package gh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestTokenOwner(t *testing.T) {
+	tests := map[string]struct {
+		scopes     string
+		wantScopes []string
+	}{
+		"classic token": {
+			scopes:     "repo, read:org",
+			wantScopes: []string{"repo", "read:org"},
+		},
+		"fine-grained token": {},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/user" {
+					http.NotFound(w, r)
+					return
+				}
+				if tt.scopes != "" {
+					w.Header().Set("X-OAuth-Scopes", tt.scopes)
+				}
+				fmt.Fprint(w, `{"login":"testuser"}`)
+			})
+
+			login, scopes, err := client.TokenOwner(context.Background())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if login != "testuser" {
+				t.Errorf("Expected testuser, got %s", login)
+			}
+			if !slices.Equal(scopes, tt.wantScopes) {
+				t.Errorf("Expected scopes %v, got %v", tt.wantScopes, scopes)
+			}
+		})
+	}
+}
+
+func TestTokenOwnerRevalidated(t *testing.T) {
+	scopes, notModified := "read:org", 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("X-OAuth-Scopes", scopes)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"login":"testuser"}`)
+	})
+
+	if _, _, err := client.TokenOwner(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Adding a scope to the token doesn't change the user, so the cache is revalidated
+	scopes = "repo, read:org"
+	login, got, err := client.TokenOwner(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if notModified != 1 {
+		t.Fatalf("Expected the cached user to be revalidated, got %d revalidations", notModified)
+	}
+	if login != "testuser" {
+		t.Errorf("Expected testuser, got %s", login)
+	}
+	if want := []string{"repo", "read:org"}; !slices.Equal(got, want) {
+		t.Errorf("Expected scopes %v, got %v", want, got)
+	}
+}
+
+func TestRepoAccess(t *testing.T) {
+	tests := map[string]struct {
+		repo         string
+		permissions  string
+		wantReadOnly bool
+		wantErr      bool
+	}{
+		"writable": {
+			repo:        "owner/repo",
+			permissions: `,"permissions":{"pull":true,"push":true}`,
+		},
+		"read-only": {
+			repo:         "owner/repo",
+			permissions:  `,"permissions":{"pull":true,"push":false}`,
+			wantReadOnly: true,
+		},
+		"permissions not reported": {
+			repo: "owner/repo",
+		},
+		"not accessible": {
+			repo:    "owner/private",
+			wantErr: true,
+		},
+		"invalid format": {
+			repo:    "repo",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/repos/owner/repo":
+					fmt.Fprintf(w, `{"full_name":"owner/repo"%s}`, tt.permissions)
+				case "/repos/owner/repo/pulls":
+					fmt.Fprint(w, `[{"number":1,"head":{"ref":"feature/PROJ-1"}},{"number":2,"head":{"ref":"fix-typo"}}]`)
+				default:
+					http.NotFound(w, r)
+				}
+			})
+
+			access, err := client.RepoAccess(context.Background(), tt.repo)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if access.ReadOnly != tt.wantReadOnly {
+				t.Errorf("Expected ReadOnly %v, got %v", tt.wantReadOnly, access.ReadOnly)
+			}
+			if want := []string{"feature/PROJ-1", "fix-typo"}; !slices.Equal(access.Branches, want) {
+				t.Errorf("Expected branches %v, got %v", want, access.Branches)
+			}
+		})
+	}
+}
+
+func TestListRepos(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user/repos" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/user/repos?page=2>; rel="next"`, r.Host))
+			fmt.Fprint(w, `[{"full_name":"owner/repo1"}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"full_name":"owner/repo2"}]`)
+	})
+
+	repos, err := client.ListRepos(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := []string{"owner/repo1", "owner/repo2"}; !slices.Equal(repos, want) {
+		t.Errorf("Expected %v, got %v", want, repos)
+	}
+}
@@ -0,0 +1,128 @@
+package gh
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/go-github/v79/github"
+	"github.com/pippokairos/workflow-monitor/internal/config"
+	"github.com/pippokairos/workflow-monitor/internal/debug"
+)
+
+// Installation tokens are valid for an hour, refresh them a bit before they expire.
+const installationTokenRefreshMargin = 5 * time.Minute
+
+// installationTokenSource mints GitHub App installation tokens, reusing each one
+// until it's about to expire.
+type installationTokenSource struct {
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+	baseURL        string
+	uploadURL      string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func newInstallationTokenSource(cfg *config.Config) (*installationTokenSource, error) {
+	pem, err := os.ReadFile(cfg.GitHubAppPrivateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GitHub App private key: %w", err)
+	}
+
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %w", err)
+	}
+
+	return &installationTokenSource{
+		appID:          cfg.GitHubAppID,
+		installationID: cfg.GitHubAppInstallationID,
+		key:            key,
+		baseURL:        cfg.GitHubBaseURL,
+		uploadURL:      cfg.GitHubUploadURL,
+	}, nil
+}
+
+func (s *installationTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Until(s.expiresAt) > installationTokenRefreshMargin {
+		return s.token, nil
+	}
+
+	appJWT, err := s.appJWT()
+	if err != nil {
+		return "", err
+	}
+
+	appClient := github.NewClient(nil).WithAuthToken(appJWT)
+	if s.baseURL != "" {
+		uploadURL := s.uploadURL
+		if uploadURL == "" {
+			uploadURL = s.baseURL
+		}
+
+		appClient, err = appClient.WithEnterpriseURLs(s.baseURL, uploadURL)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	token, resp, err := appClient.Apps.CreateInstallationToken(ctx, s.installationID, nil)
+	debug.Printf("GitHub Apps CreateInstallationToken response: %+v", resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to create GitHub App installation token: %w", err)
+	}
+
+	s.token = token.GetToken()
+	s.expiresAt = token.GetExpiresAt().Time
+	debug.Printf("Minted GitHub App installation token, expires at %s", s.expiresAt)
+
+	return s.token, nil
+}
+
+// appJWT signs the short-lived JWT that authenticates as the App itself.
+func (s *installationTokenSource) appJWT() (string, error) {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		// Backdated to allow for clock drift, as recommended by GitHub
+		IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(9 * time.Minute)),
+		Issuer:    strconv.FormatInt(s.appID, 10),
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+
+	return signed, nil
+}
+
+type appAuthTransport struct {
+	base   http.RoundTripper
+	source *installationTokenSource
+}
+
+func (t *appAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,83 @@
+package gh
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func TestGitHubAppAuthentication(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyFile := filepath.Join(t.TempDir(), "app.pem")
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	mints := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		switch r.URL.Path {
+		case "/api/v3/app/installations/42/access_tokens":
+			mints++
+			claims := &jwt.RegisteredClaims{}
+			_, err := jwt.ParseWithClaims(auth, claims, func(*jwt.Token) (any, error) {
+				return &key.PublicKey, nil
+			})
+			if err != nil || claims.Issuer != "7" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token":"installation-token","expires_at":"%s"}`, time.Now().Add(time.Hour).Format(time.RFC3339))
+		case "/api/v3/search/issues":
+			if auth != "installation-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"items":[]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig()
+	cfg.GitHubToken = ""
+	cfg.GitHubBaseURL = server.URL
+	cfg.GitHubAppID = 7
+	cfg.GitHubAppPrivateKeyFile = keyFile
+	cfg.GitHubAppInstallationID = 42
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	for range 2 {
+		if _, err := client.FetchPRsNeedingMyReview(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if mints != 1 {
+		t.Errorf("Expected the installation token to be minted once, got %d", mints)
+	}
+}
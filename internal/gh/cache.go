@@ -0,0 +1,199 @@
+package gh
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pippokairos/workflow-monitor/internal/debug"
+)
+
+type cacheEntry struct {
+	ETag         string      `json:"etag"`
+	LastModified string      `json:"last_modified"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+}
+
+// cacheTransport revalidates GET requests with the ETag or Last-Modified of the
+// previous response. GitHub doesn't count 304 responses against the rate limit, so
+// unchanged resources are served from the cache almost for free.
+type cacheTransport struct {
+	base     http.RoundTripper
+	dir      string // Optional, entries are also persisted here when set
+	identity string // Credentials the cached responses belong to
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+func newCacheTransport(base http.RoundTripper, dir, identity string) *cacheTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			debug.Printf("Error creating GitHub cache dir %s, caching in memory only: %v", dir, err)
+			dir = ""
+		}
+	}
+
+	return &cacheTransport{
+		base:     base,
+		dir:      dir,
+		identity: identity,
+		entries:  make(map[string]*cacheEntry),
+	}
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+
+	key := t.cacheKey(req)
+	entry := t.get(key)
+	if entry != nil {
+		req = req.Clone(req.Context())
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		debug.Printf("GitHub cache hit for %s", req.URL)
+		resp.Body.Close()
+		return entry.response(req, resp), nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.put(key, &cacheEntry{
+		ETag:         etag,
+		LastModified: lastModified,
+		Header:       resp.Header.Clone(),
+		Body:         body,
+	})
+
+	return resp, nil
+}
+
+func (t *cacheTransport) get(key string) *cacheEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if entry, ok := t.entries[key]; ok {
+		return entry
+	}
+
+	if t.dir == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(t.dir, key+".json"))
+	if err != nil {
+		return nil
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		debug.Printf("Error reading GitHub cache entry %s: %v", key, err)
+		return nil
+	}
+	t.entries[key] = &entry
+
+	return &entry
+}
+
+func (t *cacheTransport) put(key string, entry *cacheEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.entries[key] = entry
+
+	if t.dir == "" {
+		return
+	}
+
+	data, err := json.Marshal(entry)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(t.dir, key+".json"), data, 0o600)
+	}
+	if err != nil {
+		debug.Printf("Error writing GitHub cache entry %s: %v", key, err)
+	}
+}
+
+// Headers taken from the 304 revalidating a cached response, as they describe the
+// credentials rather than the resource: scopes can be added to a classic token
+// without changing its cached responses.
+var credentialHeaders = []string{"X-Oauth-Scopes", "X-Accepted-Oauth-Scopes"}
+
+// response rebuilds a 200 response from the cached entry, keeping the fresh rate
+// limit and credential headers of the 304 that revalidated it.
+func (e *cacheEntry) response(req *http.Request, notModified *http.Response) *http.Response {
+	header := e.Header.Clone()
+	for name, values := range notModified.Header {
+		if strings.HasPrefix(name, "X-Ratelimit-") || slices.Contains(credentialHeaders, name) {
+			header[name] = values
+		}
+	}
+	// Tells go-github the response didn't come from the network
+	header.Set("X-From-Cache", "1")
+	header.Set("Content-Length", strconv.Itoa(len(e.Body)))
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// cacheKey identifies a response by URL, representation and credentials, so
+// entries are never shared between tokens.
+func (t *cacheTransport) cacheKey(req *http.Request) string {
+	h := sha256.New()
+	io.WriteString(h, req.URL.String())
+	io.WriteString(h, "\n"+req.Header.Get("Accept"))
+	io.WriteString(h, "\n"+t.identity)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
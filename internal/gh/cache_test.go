@@ -0,0 +1,59 @@
+package gh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCacheTransportRevalidates(t *testing.T) {
+	for name, dir := range map[string]string{"memory": "", "disk": t.TempDir()} {
+		t.Run(name, func(t *testing.T) {
+			requests, notModified := 0, 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.Header.Get("If-None-Match") == `"v1"` {
+					notModified++
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("ETag", `"v1"`)
+				fmt.Fprint(w, `{"items":[{"number":1,"title":"first","user":{"login":"a"}}]}`)
+			}))
+			defer server.Close()
+
+			cfg := newTestConfig()
+			cfg.GitHubCacheDir = dir
+			client := newServerClient(t, server.URL, cfg)
+
+			for range 2 {
+				prs, err := client.FetchPRsNeedingMyReview(context.Background())
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if len(prs) != 1 || prs[0].Number != 1 {
+					t.Fatalf("Unexpected PRs: %+v", prs)
+				}
+			}
+
+			if requests != 2 || notModified != 1 {
+				t.Errorf("Expected 2 requests with 1 revalidation, got %d and %d", requests, notModified)
+			}
+
+			if dir == "" {
+				return
+			}
+
+			// A new client picks the entry up from disk
+			client = newServerClient(t, server.URL, cfg)
+			if _, err := client.FetchPRsNeedingMyReview(context.Background()); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if notModified != 2 {
+				t.Errorf("Expected the disk cache to be revalidated, got %d revalidations", notModified)
+			}
+		})
+	}
+}
@@ -3,32 +3,118 @@ package gh
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"slices"
 	"strings"
-	"sync"
+	"sync/atomic"
 
 	"github.com/google/go-github/v79/github"
 	"github.com/pippokairos/workflow-monitor/internal/config"
 	"github.com/pippokairos/workflow-monitor/internal/debug"
 )
 
+const (
+	defaultPerPage        = 100
+	defaultMaxConcurrency = 8
+)
+
 type Client struct {
-	github   *github.Client
-	username string
-	repos    []string
+	github         *github.Client
+	username       string
+	repos          []string
+	perPage        int
+	maxConcurrency int
+	api            string
+
+	defaultMergeMethod string
+	mergeMethods       map[string]string
+
+	rateLimits      *rateLimitTransport
+	lastOpenPRCount atomic.Int64
 }
 
-func NewClient(cfg *config.Config) *Client {
-	return &Client{
-		github:   github.NewClient(nil).WithAuthToken(cfg.GitHubToken),
-		username: cfg.GitHubUsername,
-		repos:    cfg.GitHubRepos,
+func NewClient(cfg *config.Config) (*Client, error) {
+	perPage := cfg.GitHubPerPage
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+
+	maxConcurrency := cfg.GitHubMaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = defaultMaxConcurrency
+	}
+
+	rateLimits := newRateLimitTransport(http.DefaultTransport)
+
+	var githubClient *github.Client
+	if cfg.UsesGitHubApp() {
+		source, err := newInstallationTokenSource(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		identity := fmt.Sprintf("app:%d:%d", cfg.GitHubAppID, cfg.GitHubAppInstallationID)
+		transport := &appAuthTransport{
+			base:   newCacheTransport(rateLimits, cfg.GitHubCacheDir, identity),
+			source: source,
+		}
+		githubClient = github.NewClient(&http.Client{Transport: transport})
+	} else {
+		transport := newCacheTransport(rateLimits, cfg.GitHubCacheDir, cfg.GitHubToken)
+		githubClient = github.NewClient(&http.Client{Transport: transport}).WithAuthToken(cfg.GitHubToken)
+	}
+
+	if cfg.GitHubBaseURL != "" {
+		uploadURL := cfg.GitHubUploadURL
+		if uploadURL == "" {
+			uploadURL = cfg.GitHubBaseURL
+		}
+
+		var err error
+		githubClient, err = githubClient.WithEnterpriseURLs(cfg.GitHubBaseURL, uploadURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GitHub Enterprise URLs: %w", err)
+		}
 	}
+
+	return &Client{
+		github:         githubClient,
+		username:       cfg.GitHubUsername,
+		repos:          cfg.GitHubRepos,
+		perPage:        perPage,
+		maxConcurrency: maxConcurrency,
+		api:            cfg.GitHubAPI,
+
+		defaultMergeMethod: cfg.GitHubMergeMethod,
+		mergeMethods:       cfg.GitHubMergeMethods,
+
+		rateLimits: rateLimits,
+	}, nil
 }
 
 func (c *Client) FetchOpenPRs(ctx context.Context) ([]PullRequest, error) {
-	var allOpenPRs []PullRequest
-	var mu sync.Mutex
+	var prs []PullRequest
+	var err error
+
+	if c.api == config.GitHubAPIGraphQL {
+		prs, err = c.fetchOpenPRsGraphQL(ctx)
+	} else {
+		prs, err = c.fetchOpenPRsREST(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.lastOpenPRCount.Store(int64(len(prs)))
+
+	return prs, nil
+}
+
+func (c *Client) fetchOpenPRsREST(ctx context.Context) ([]PullRequest, error) {
+	pool := newWorkerPool(ctx, c.maxConcurrency)
+
+	// Results are stored by repo and PR index so the output order doesn't depend on scheduling
+	repoPRs := make([][]*PullRequest, len(c.repos))
 
 	for i := range c.repos {
 		owner, repo, err := getOwnerAndRepo(c.repos[i])
@@ -37,47 +123,90 @@ func (c *Client) FetchOpenPRs(ctx context.Context) ([]PullRequest, error) {
 			continue
 		}
 
-		options := &github.PullRequestListOptions{State: "open"}
-		githubPRs, resp, err := c.github.PullRequests.List(ctx, owner, repo, options)
-		debug.Printf("GitHub PullRequests List response: %+v", resp)
-		if err != nil {
-			return nil, err
-		}
+		pool.Go(func(ctx context.Context) error {
+			githubPRs, err := c.listOpenPRs(ctx, owner, repo)
+			if err != nil {
+				return err
+			}
 
-		if len(githubPRs) == 0 {
-			continue
-		}
+			prs := make([]*PullRequest, len(githubPRs))
+			repoPRs[i] = prs
+
+			for j, githubPR := range githubPRs {
+				pool.Go(func(ctx context.Context) error {
+					approvers, err := c.FetchApprovers(ctx, owner, repo, githubPR)
+					if err != nil {
+						debug.Printf("Error fetching approvers: %v", err)
+						return nil
+					}
 
-		var wg sync.WaitGroup
+					prs[j] = ToInternalPullRequest(githubPR, approvers)
+					return nil
+				})
+			}
 
-		for _, githubPR := range githubPRs {
-			wg.Add(1)
-			go func(githubPR *github.PullRequest) {
-				defer wg.Done()
+			return nil
+		})
+	}
 
-				approvers, err := c.FetchApprovers(ctx, owner, repo, githubPR)
-				if err != nil {
-					debug.Printf("Error fetching approvers: %v", err)
-					return
-				}
+	if err := pool.Wait(); err != nil {
+		return nil, err
+	}
 
-				mu.Lock()
-				allOpenPRs = append(allOpenPRs, *ToInternalPullRequest(githubPR, approvers))
-				mu.Unlock()
-			}(githubPR)
+	var allOpenPRs []PullRequest
+	for _, prs := range repoPRs {
+		for _, pr := range prs {
+			if pr != nil {
+				allOpenPRs = append(allOpenPRs, *pr)
+			}
 		}
-
-		wg.Wait()
 	}
 
 	return allOpenPRs, nil
 }
 
+func (c *Client) listOpenPRs(ctx context.Context, owner, repo string) ([]*github.PullRequest, error) {
+	options := &github.PullRequestListOptions{
+		State:       "open",
+		ListOptions: github.ListOptions{PerPage: c.perPage},
+	}
+
+	var githubPRs []*github.PullRequest
+	for {
+		page, resp, err := c.github.PullRequests.List(ctx, owner, repo, options)
+		debug.Printf("GitHub PullRequests List response: %+v", resp)
+		if err != nil {
+			return nil, err
+		}
+
+		githubPRs = append(githubPRs, page...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+
+	return githubPRs, nil
+}
+
 func (c *Client) FetchApprovers(ctx context.Context, owner, repo string, githubPR *github.PullRequest) ([]string, error) {
-	reviews, resp, err := c.github.PullRequests.ListReviews(ctx, owner, repo, githubPR.GetNumber(), nil)
-	debug.Printf("GitHub PullRequest ListReviews response: %+v", resp)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch reviews for PR #%d: %w", githubPR.GetNumber(), err)
+	options := &github.ListOptions{PerPage: c.perPage}
+
+	var reviews []*github.PullRequestReview
+	for {
+		page, resp, err := c.github.PullRequests.ListReviews(ctx, owner, repo, githubPR.GetNumber(), options)
+		debug.Printf("GitHub PullRequest ListReviews response: %+v", resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch reviews for PR #%d: %w", githubPR.GetNumber(), err)
+		}
+
+		reviews = append(reviews, page...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
 	}
 
 	var approvers []string
@@ -98,15 +227,24 @@ func (c *Client) FetchPRsNeedingMyReview(ctx context.Context) ([]PullRequest, er
 		query += fmt.Sprintf(" repo:%s", c.repos[i])
 	}
 
-	opts := &github.SearchOptions{}
-	result, _, err := c.github.Search.Issues(ctx, query, opts)
-	if err != nil {
-		return nil, err
-	}
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: c.perPage}}
 
 	var prs []PullRequest
-	for _, issue := range result.Issues {
-		prs = append(prs, *ToPullRequest(issue))
+	for {
+		result, resp, err := c.github.Search.Issues(ctx, query, opts)
+		debug.Printf("GitHub Search Issues response: %+v", resp)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, issue := range result.Issues {
+			prs = append(prs, *ToPullRequest(issue))
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
 	}
 
 	return prs, nil
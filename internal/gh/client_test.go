@@ -1,7 +1,14 @@
 package gh
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
+
+	"github.com/pippokairos/workflow-monitor/internal/config"
 )
 
 func TestGetOwnerAndRepo(t *testing.T) {
@@ -56,3 +63,133 @@ func TestGetOwnerAndRepo(t *testing.T) {
 		})
 	}
 }
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return newServerClient(t, server.URL, newTestConfig())
+}
+
+func newTestConfig() *config.Config {
+	return &config.Config{
+		GitHubToken:    "gh-token",
+		GitHubUsername: "testuser",
+		GitHubRepos:    []string{"owner/repo"},
+	}
+}
+
+func newServerClient(t *testing.T, serverURL string, cfg *config.Config) *Client {
+	t.Helper()
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	baseURL, err := url.Parse(serverURL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.github.BaseURL = baseURL
+
+	return client
+}
+
+func TestFetchPRsNeedingMyReviewPaginates(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/search/issues?page=2>; rel="next"`, r.Host))
+			fmt.Fprint(w, `{"items":[{"number":1,"title":"first","user":{"login":"a"}}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"items":[{"number":2,"title":"second","user":{"login":"b"}}]}`)
+	})
+
+	prs, err := client.FetchPRsNeedingMyReview(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(prs) != 2 {
+		t.Fatalf("Expected 2 PRs, got %d", len(prs))
+	}
+	if prs[1].Number != 2 {
+		t.Errorf("Expected PR #2, got #%d", prs[1].Number)
+	}
+}
+
+func TestFetchOpenPRsPaginates(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/pulls":
+			if r.URL.Query().Get("page") == "" {
+				w.Header().Set("Link", fmt.Sprintf(`<http://%s/repos/owner/repo/pulls?page=2>; rel="next"`, r.Host))
+				fmt.Fprint(w, `[{"number":1,"user":{"login":"a"},"head":{"ref":"PROJ-1"}}]`)
+				return
+			}
+			fmt.Fprint(w, `[{"number":2,"user":{"login":"b"},"head":{"ref":"PROJ-2"}}]`)
+		case "/repos/owner/repo/pulls/1/reviews", "/repos/owner/repo/pulls/2/reviews":
+			if r.URL.Query().Get("page") == "" {
+				w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=2>; rel="next"`, r.Host, r.URL.Path))
+				fmt.Fprint(w, `[{"state":"APPROVED","user":{"login":"reviewer1"}}]`)
+				return
+			}
+			fmt.Fprint(w, `[{"state":"APPROVED","user":{"login":"reviewer2"}}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	prs, err := client.FetchOpenPRs(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(prs) != 2 {
+		t.Fatalf("Expected 2 PRs, got %d", len(prs))
+	}
+	for _, pr := range prs {
+		if len(pr.Approvers) != 2 {
+			t.Errorf("Expected 2 approvers for PR #%d, got %v", pr.Number, pr.Approvers)
+		}
+	}
+}
+
+func TestNewClientEnterpriseURLs(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v3/search/issues":
+			fmt.Fprint(w, `{"items":[]}`)
+		case "/api/graphql":
+			fmt.Fprint(w, `{"data":{"r0":{"pullRequests":{"pageInfo":{"hasNextPage":false},"nodes":[]}}}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig()
+	cfg.GitHubBaseURL = server.URL
+	cfg.GitHubAPI = config.GitHubAPIGraphQL
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if _, err := client.FetchPRsNeedingMyReview(context.Background()); err != nil {
+		t.Fatalf("Unexpected search error: %v", err)
+	}
+	if _, err := client.FetchOpenPRs(context.Background()); err != nil {
+		t.Fatalf("Unexpected GraphQL error: %v", err)
+	}
+
+	if len(paths) != 2 {
+		t.Errorf("Expected 2 requests, got %v", paths)
+	}
+}
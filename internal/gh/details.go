@@ -0,0 +1,149 @@
+package gh
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v79/github"
+	"github.com/pippokairos/workflow-monitor/internal/debug"
+)
+
+type Review struct {
+	Author string
+	State  string
+}
+
+// PRDetails holds what's shown in the detail pane, which the list endpoints don't
+// return (or return only with the GraphQL backend).
+type PRDetails struct {
+	Body               string
+	Branch             string
+	Base               string
+	Reviews            []Review // Latest review of each reviewer
+	RequestedReviewers []string
+	ChecksState        string // success, failure, pending or empty without checks
+}
+
+func (c *Client) FetchPRDetails(ctx context.Context, pr PullRequest) (*PRDetails, error) {
+	owner, repo, err := getOwnerAndRepo(pr.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	githubPR, resp, err := c.github.PullRequests.Get(ctx, owner, repo, pr.Number)
+	debug.Printf("GitHub PullRequests Get response: %+v", resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch PR #%d: %w", pr.Number, err)
+	}
+
+	reviews, err := c.fetchLatestReviews(ctx, owner, repo, pr.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	checksState, err := c.fetchChecksState(ctx, owner, repo, githubPR.GetHead().GetSHA())
+	if err != nil {
+		return nil, err
+	}
+
+	return &PRDetails{
+		Body:               githubPR.GetBody(),
+		Branch:             githubPR.GetHead().GetRef(),
+		Base:               githubPR.GetBase().GetRef(),
+		Reviews:            reviews,
+		RequestedReviewers: ToInternalPullRequest(githubPR, nil).RequestedReviewers,
+		ChecksState:        checksState,
+	}, nil
+}
+
+// fetchLatestReviews keeps the last review of each reviewer, ignoring plain comments
+// left after an approval or change request.
+func (c *Client) fetchLatestReviews(ctx context.Context, owner, repo string, number int) ([]Review, error) {
+	options := &github.ListOptions{PerPage: c.perPage}
+
+	var reviews []Review
+	latest := make(map[string]int)
+	for {
+		page, resp, err := c.github.PullRequests.ListReviews(ctx, owner, repo, number, options)
+		debug.Printf("GitHub PullRequest ListReviews response: %+v", resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch reviews for PR #%d: %w", number, err)
+		}
+
+		for _, review := range page {
+			author, state := review.GetUser().GetLogin(), review.GetState()
+			i, ok := latest[author]
+			switch {
+			case !ok:
+				latest[author] = len(reviews)
+				reviews = append(reviews, Review{Author: author, State: state})
+			case state != "COMMENTED":
+				reviews[i].State = state
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+
+	return reviews, nil
+}
+
+// fetchChecksState combines the commit statuses and check runs of a commit into a
+// single state, like the status check rollup of the GraphQL API.
+func (c *Client) fetchChecksState(ctx context.Context, owner, repo, sha string) (string, error) {
+	status, resp, err := c.github.Repositories.GetCombinedStatus(ctx, owner, repo, sha, nil)
+	debug.Printf("GitHub Repositories GetCombinedStatus response: %+v", resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch statuses of %s: %w", sha, err)
+	}
+
+	runs, resp, err := c.github.Checks.ListCheckRunsForRef(ctx, owner, repo, sha, &github.ListCheckRunsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	})
+	debug.Printf("GitHub Checks ListCheckRunsForRef response: %+v", resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch check runs of %s: %w", sha, err)
+	}
+
+	return rollupChecksState(status, runs.CheckRuns), nil
+}
+
+func rollupChecksState(status *github.CombinedStatus, runs []*github.CheckRun) string {
+	if status.GetTotalCount() == 0 && len(runs) == 0 {
+		return ""
+	}
+
+	failed, pending := false, false
+	if status.GetTotalCount() > 0 {
+		switch status.GetState() {
+		case "failure", "error":
+			failed = true
+		case "pending":
+			pending = true
+		}
+	}
+
+	for _, run := range runs {
+		if run.GetStatus() != "completed" {
+			pending = true
+			continue
+		}
+
+		switch run.GetConclusion() {
+		case "failure", "timed_out", "cancelled", "action_required", "startup_failure":
+			failed = true
+		}
+	}
+
+	switch {
+	case failed:
+		return "failure"
+	case pending:
+		return "pending"
+	default:
+		return "success"
+	}
+}
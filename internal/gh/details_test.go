@@ -0,0 +1,53 @@
+package gh
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v79/github"
+)
+
+func TestRollupChecksState(t *testing.T) {
+	completed := func(conclusion string) *github.CheckRun {
+		return &github.CheckRun{Status: github.Ptr("completed"), Conclusion: github.Ptr(conclusion)}
+	}
+
+	tests := map[string]struct {
+		status *github.CombinedStatus
+		runs   []*github.CheckRun
+		want   string
+	}{
+		"no checks": {
+			// GitHub reports pending when a commit has no statuses at all
+			status: &github.CombinedStatus{State: github.Ptr("pending"), TotalCount: github.Ptr(0)},
+			want:   "",
+		},
+		"all passing": {
+			status: &github.CombinedStatus{State: github.Ptr("success"), TotalCount: github.Ptr(1)},
+			runs:   []*github.CheckRun{completed("success"), completed("skipped")},
+			want:   "success",
+		},
+		"failing status": {
+			status: &github.CombinedStatus{State: github.Ptr("error"), TotalCount: github.Ptr(1)},
+			runs:   []*github.CheckRun{completed("success")},
+			want:   "failure",
+		},
+		"running check": {
+			status: &github.CombinedStatus{TotalCount: github.Ptr(0)},
+			runs:   []*github.CheckRun{completed("success"), {Status: github.Ptr("in_progress")}},
+			want:   "pending",
+		},
+		"failure wins over pending": {
+			status: &github.CombinedStatus{State: github.Ptr("pending"), TotalCount: github.Ptr(1)},
+			runs:   []*github.CheckRun{completed("timed_out")},
+			want:   "failure",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := rollupChecksState(tt.status, tt.runs); got != tt.want {
+				t.Errorf("Expected '%s', got '%s'", tt.want, got)
+			}
+		})
+	}
+}
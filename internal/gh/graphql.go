@@ -0,0 +1,287 @@
+package gh
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/pippokairos/workflow-monitor/internal/debug"
+)
+
+// Number of repositories queried together in a single GraphQL request.
+const graphQLReposPerQuery = 10
+
+const graphQLPullRequestFields = `
+	pageInfo { hasNextPage endCursor }
+	nodes {
+		number
+		title
+		url
+		state
+		headRefName
+		author { login }
+		baseRepository { nameWithOwner }
+		reviews(first: 100, states: APPROVED) { nodes { author { login } } }
+		reviewRequests(first: 50) {
+			nodes {
+				requestedReviewer {
+					... on User { login }
+					... on Team { slug }
+					... on Mannequin { login }
+				}
+			}
+		}
+		commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+	}`
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+type graphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	Path    []any  `json:"path"`
+}
+
+// notFoundAlias returns the alias of the repository the error is about when it
+// only means that this repository doesn't exist or isn't accessible.
+func (e graphQLError) notFoundAlias() (string, bool) {
+	if e.Type != "NOT_FOUND" || len(e.Path) != 1 {
+		return "", false
+	}
+
+	alias, ok := e.Path[0].(string)
+	return alias, ok
+}
+
+type graphQLPullRequest struct {
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	State       string `json:"state"`
+	HeadRefName string `json:"headRefName"`
+	Author      *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	BaseRepository struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"baseRepository"`
+	Reviews struct {
+		Nodes []struct {
+			Author *struct {
+				Login string `json:"login"`
+			} `json:"author"`
+		} `json:"nodes"`
+	} `json:"reviews"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer *struct {
+				Login string `json:"login"`
+				Slug  string `json:"slug"`
+			} `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State string `json:"state"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+type graphQLRepository struct {
+	PullRequests struct {
+		PageInfo struct {
+			HasNextPage bool   `json:"hasNextPage"`
+			EndCursor   string `json:"endCursor"`
+		} `json:"pageInfo"`
+		Nodes []graphQLPullRequest `json:"nodes"`
+	} `json:"pullRequests"`
+}
+
+type graphQLRepositoriesResponse struct {
+	Data   map[string]*graphQLRepository `json:"data"`
+	Errors []graphQLError                `json:"errors"`
+}
+
+// repoCursor tracks the pagination state of a single repository across GraphQL rounds.
+type repoCursor struct {
+	owner  string
+	name   string
+	cursor string
+	done   bool
+}
+
+// fetchOpenPRsGraphQL retrieves open PRs with their approvals, review requests and
+// checks for all repositories, batching several repositories per query and only
+// re-querying the ones that still have more pages.
+func (c *Client) fetchOpenPRsGraphQL(ctx context.Context) ([]PullRequest, error) {
+	var cursors []*repoCursor
+	for i := range c.repos {
+		owner, repo, err := getOwnerAndRepo(c.repos[i])
+		if err != nil {
+			debug.Printf("Error parsing repo %s: %v", c.repos[i], err)
+			continue
+		}
+		cursors = append(cursors, &repoCursor{owner: owner, name: repo})
+	}
+
+	var allOpenPRs []PullRequest
+	queries := 0
+	for {
+		var pending []*repoCursor
+		for _, rc := range cursors {
+			if !rc.done {
+				pending = append(pending, rc)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+
+		for batch := range slices.Chunk(pending, graphQLReposPerQuery) {
+			prs, err := c.queryRepositories(ctx, batch)
+			if err != nil {
+				return nil, err
+			}
+			queries++
+			allOpenPRs = append(allOpenPRs, prs...)
+		}
+	}
+
+	debug.Printf("Fetched %d open PRs from %d repos in %d GraphQL queries", len(allOpenPRs), len(cursors), queries)
+
+	return allOpenPRs, nil
+}
+
+func (c *Client) queryRepositories(ctx context.Context, batch []*repoCursor) ([]PullRequest, error) {
+	var declarations, selections []string
+	variables := make(map[string]any, len(batch)*3)
+
+	for i, rc := range batch {
+		declarations = append(declarations, fmt.Sprintf("$owner%d: String!, $name%d: String!, $after%d: String", i, i, i))
+		selections = append(selections, fmt.Sprintf(
+			"r%d: repository(owner: $owner%d, name: $name%d) { pullRequests(states: OPEN, first: %d, after: $after%d) { %s } }",
+			i, i, i, min(c.perPage, 100), i, graphQLPullRequestFields,
+		))
+
+		variables[fmt.Sprintf("owner%d", i)] = rc.owner
+		variables[fmt.Sprintf("name%d", i)] = rc.name
+		if rc.cursor != "" {
+			variables[fmt.Sprintf("after%d", i)] = rc.cursor
+		}
+	}
+
+	query := fmt.Sprintf("query(%s) {\n%s\n}", strings.Join(declarations, ", "), strings.Join(selections, "\n"))
+
+	var result graphQLRepositoriesResponse
+	if err := c.doGraphQL(ctx, graphQLRequest{Query: query, Variables: variables}, &result); err != nil {
+		return nil, err
+	}
+
+	// A renamed or inaccessible repo only skips itself, its alias being null
+	for _, graphQLErr := range result.Errors {
+		if alias, ok := graphQLErr.notFoundAlias(); ok {
+			debug.Printf("GitHub GraphQL %s: %s", alias, graphQLErr.Message)
+			continue
+		}
+		return nil, fmt.Errorf("GitHub GraphQL query failed: %s", graphQLErr.Message)
+	}
+
+	var prs []PullRequest
+	for i, rc := range batch {
+		repository := result.Data[fmt.Sprintf("r%d", i)]
+		if repository == nil {
+			debug.Printf("Repository %s/%s not found via GraphQL", rc.owner, rc.name)
+			rc.done = true
+			continue
+		}
+
+		for j := range repository.PullRequests.Nodes {
+			prs = append(prs, *fromGraphQLPullRequest(&repository.PullRequests.Nodes[j]))
+		}
+
+		pageInfo := repository.PullRequests.PageInfo
+		rc.cursor = pageInfo.EndCursor
+		rc.done = !pageInfo.HasNextPage
+	}
+
+	return prs, nil
+}
+
+func (c *Client) doGraphQL(ctx context.Context, body graphQLRequest, v any) error {
+	req, err := c.github.NewRequest("POST", graphQLPath(c.github.BaseURL), body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.github.Do(ctx, req, v)
+	debug.Printf("GitHub GraphQL response: %+v", resp)
+	if err != nil {
+		return fmt.Errorf("GitHub GraphQL request failed: %w", err)
+	}
+
+	return nil
+}
+
+// graphQLPath resolves the GraphQL endpoint against the REST base URL, which is
+// /api/graphql rather than /api/v3/graphql on GitHub Enterprise Server.
+func graphQLPath(baseURL *url.URL) string {
+	if strings.HasSuffix(baseURL.Path, "/api/v3/") {
+		return "../graphql"
+	}
+
+	return "graphql"
+}
+
+func fromGraphQLPullRequest(pr *graphQLPullRequest) *PullRequest {
+	var author string
+	if pr.Author != nil {
+		author = pr.Author.Login
+	}
+
+	approvers := []string{}
+	for _, review := range pr.Reviews.Nodes {
+		if review.Author != nil && !slices.Contains(approvers, review.Author.Login) {
+			approvers = append(approvers, review.Author.Login)
+		}
+	}
+
+	var requestedReviewers []string
+	for _, request := range pr.ReviewRequests.Nodes {
+		reviewer := request.RequestedReviewer
+		if reviewer == nil {
+			continue
+		}
+		if reviewer.Login != "" {
+			requestedReviewers = append(requestedReviewers, reviewer.Login)
+		} else if reviewer.Slug != "" {
+			requestedReviewers = append(requestedReviewers, reviewer.Slug)
+		}
+	}
+
+	var checksState string
+	if len(pr.Commits.Nodes) > 0 && pr.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+		checksState = strings.ToLower(pr.Commits.Nodes[0].Commit.StatusCheckRollup.State)
+	}
+
+	return &PullRequest{
+		URL:                pr.URL,
+		Number:             pr.Number,
+		Title:              pr.Title,
+		State:              strings.ToLower(pr.State),
+		BranchName:         pr.HeadRefName,
+		Author:             author,
+		Repo:               pr.BaseRepository.NameWithOwner,
+		Approvers:          approvers,
+		RequestedReviewers: requestedReviewers,
+		ChecksState:        checksState,
+	}
+}
@@ -0,0 +1,122 @@
+package gh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pippokairos/workflow-monitor/internal/config"
+)
+
+func TestFetchOpenPRsGraphQL(t *testing.T) {
+	queries := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" {
+			http.NotFound(w, r)
+			return
+		}
+		queries++
+
+		var req graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if req.Variables["after0"] == nil {
+			fmt.Fprint(w, `{"data":{"r0":{"pullRequests":{
+				"pageInfo":{"hasNextPage":true,"endCursor":"c1"},
+				"nodes":[{"number":1,"title":"first","state":"OPEN","headRefName":"PROJ-1-fix",
+					"author":{"login":"a"},"baseRepository":{"nameWithOwner":"owner/repo"},
+					"reviews":{"nodes":[{"author":{"login":"r1"}},{"author":{"login":"r1"}},{"author":{"login":"r2"}}]},
+					"reviewRequests":{"nodes":[{"requestedReviewer":{"login":"r3"}},{"requestedReviewer":{"slug":"team"}}]},
+					"commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"SUCCESS"}}}]}}]}}}}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"data":{"r0":{"pullRequests":{
+			"pageInfo":{"hasNextPage":false},
+			"nodes":[{"number":2,"title":"second","state":"OPEN","headRefName":"PROJ-2-feat",
+				"author":{"login":"b"},"baseRepository":{"nameWithOwner":"owner/repo"},
+				"reviews":{"nodes":[]},"reviewRequests":{"nodes":[]},"commits":{"nodes":[]}}]}}}}`)
+	})
+	client.api = config.GitHubAPIGraphQL
+
+	prs, err := client.FetchOpenPRs(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if queries != 2 {
+		t.Errorf("Expected 2 queries, got %d", queries)
+	}
+
+	if len(prs) != 2 {
+		t.Fatalf("Expected 2 PRs, got %d", len(prs))
+	}
+
+	first := prs[0]
+	if first.BranchName != "PROJ-1-fix" || first.Repo != "owner/repo" || first.State != "open" {
+		t.Errorf("Unexpected PR fields: %+v", first)
+	}
+	if len(first.Approvers) != 2 {
+		t.Errorf("Expected 2 unique approvers, got %v", first.Approvers)
+	}
+	if len(first.RequestedReviewers) != 2 {
+		t.Errorf("Expected 2 requested reviewers, got %v", first.RequestedReviewers)
+	}
+	if first.ChecksState != "success" {
+		t.Errorf("Expected checks state 'success', got '%s'", first.ChecksState)
+	}
+}
+
+func TestFetchOpenPRsGraphQLErrors(t *testing.T) {
+	tests := map[string]struct {
+		response string
+		wantPRs  int
+		wantErr  bool
+	}{
+		"query error": {
+			response: `{"data":null,"errors":[{"message":"Something went wrong"}]}`,
+			wantErr:  true,
+		},
+		"missing repo is skipped": {
+			response: `{"data":{"r0":null,"r1":{"pullRequests":{"pageInfo":{"hasNextPage":false},
+				"nodes":[{"number":1,"title":"first","state":"OPEN","baseRepository":{"nameWithOwner":"owner/repo2"}}]}}},
+				"errors":[{"type":"NOT_FOUND","path":["r0"],"message":"Could not resolve to a Repository with the name 'owner/repo1'."}]}`,
+			wantPRs: 1,
+		},
+		"other error along with data": {
+			response: `{"data":{"r0":null,"r1":null},
+				"errors":[{"type":"NOT_FOUND","path":["r0"],"message":"Could not resolve"},{"type":"FORBIDDEN","path":["r1"],"message":"Resource not accessible"}]}`,
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.response)
+			})
+			client.api = config.GitHubAPIGraphQL
+			client.repos = []string{"owner/repo1", "owner/repo2"}
+
+			prs, err := client.FetchOpenPRs(context.Background())
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(prs) != tt.wantPRs {
+				t.Errorf("Expected %d PRs, got %d", tt.wantPRs, len(prs))
+			}
+		})
+	}
+}
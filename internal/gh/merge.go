@@ -0,0 +1,90 @@
+package gh
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v79/github"
+	"github.com/pippokairos/workflow-monitor/internal/debug"
+)
+
+// MergePullRequest merges the PR with the merge method configured for its repo,
+// after checking GitHub considers it mergeable.
+func (c *Client) MergePullRequest(ctx context.Context, pr PullRequest) error {
+	owner, repo, err := getOwnerAndRepo(pr.Repo)
+	if err != nil {
+		return err
+	}
+
+	githubPR, resp, err := c.github.PullRequests.Get(ctx, owner, repo, pr.Number)
+	debug.Printf("GitHub PullRequests Get response: %+v", resp)
+	if err != nil {
+		return fmt.Errorf("failed to fetch PR #%d: %w", pr.Number, err)
+	}
+
+	if err := checkMergeable(githubPR); err != nil {
+		return err
+	}
+
+	method := c.mergeMethod(pr.Repo)
+	options := &github.PullRequestOptions{
+		MergeMethod: method,
+		// Only merge what was checked above
+		SHA: githubPR.GetHead().GetSHA(),
+	}
+
+	result, resp, err := c.github.PullRequests.Merge(ctx, owner, repo, pr.Number, "", options)
+	debug.Printf("GitHub PullRequests Merge response: %+v", resp)
+	if err != nil {
+		return fmt.Errorf("failed to %s PR #%d: %w", method, pr.Number, err)
+	}
+
+	if !result.GetMerged() {
+		return fmt.Errorf("PR #%d was not merged: %s", pr.Number, result.GetMessage())
+	}
+
+	return nil
+}
+
+func checkMergeable(githubPR *github.PullRequest) error {
+	number := githubPR.GetNumber()
+
+	if githubPR.GetMerged() {
+		return fmt.Errorf("PR #%d is already merged", number)
+	}
+
+	if githubPR.GetState() != "open" {
+		return fmt.Errorf("PR #%d is %s", number, githubPR.GetState())
+	}
+
+	if githubPR.GetDraft() {
+		return fmt.Errorf("PR #%d is a draft", number)
+	}
+
+	// GitHub computes mergeability in the background, so it may not be known yet
+	if githubPR.Mergeable == nil {
+		return fmt.Errorf("GitHub is still checking whether PR #%d can be merged, try again in a few seconds", number)
+	}
+
+	if !githubPR.GetMergeable() {
+		return fmt.Errorf("PR #%d can't be merged (%s)", number, githubPR.GetMergeableState())
+	}
+
+	if githubPR.GetMergeableState() == "blocked" {
+		return fmt.Errorf("PR #%d is blocked by branch protection rules", number)
+	}
+
+	return nil
+}
+
+func (c *Client) mergeMethod(repo string) string {
+	if method, ok := c.mergeMethods[repo]; ok {
+		return method
+	}
+
+	if c.defaultMergeMethod != "" {
+		return c.defaultMergeMethod
+	}
+
+	return "merge"
+}
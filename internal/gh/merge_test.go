@@ -0,0 +1,75 @@
+package gh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestMergePullRequest(t *testing.T) {
+	tests := map[string]struct {
+		pr         string
+		wantMethod string
+		wantErr    bool
+	}{
+		"clean": {
+			pr:         `{"number":1,"state":"open","mergeable":true,"mergeable_state":"clean","head":{"sha":"abc"}}`,
+			wantMethod: "squash",
+		},
+		"conflicts": {
+			pr:      `{"number":1,"state":"open","mergeable":false,"mergeable_state":"dirty"}`,
+			wantErr: true,
+		},
+		"mergeability unknown": {
+			pr:      `{"number":1,"state":"open","mergeable_state":"unknown"}`,
+			wantErr: true,
+		},
+		"blocked": {
+			pr:      `{"number":1,"state":"open","mergeable":true,"mergeable_state":"blocked"}`,
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotMethod, gotSHA string
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/repos/owner/repo/pulls/1":
+					fmt.Fprint(w, tt.pr)
+				case r.Method == http.MethodPut && r.URL.Path == "/repos/owner/repo/pulls/1/merge":
+					var body map[string]string
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					gotMethod, gotSHA = body["merge_method"], body["sha"]
+					fmt.Fprint(w, `{"merged":true}`)
+				default:
+					http.NotFound(w, r)
+				}
+			})
+			client.mergeMethods = map[string]string{"owner/repo": "squash"}
+
+			err := client.MergePullRequest(context.Background(), PullRequest{Repo: "owner/repo", Number: 1})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				if gotMethod != "" {
+					t.Error("Expected the PR not to be merged")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if gotMethod != tt.wantMethod || gotSHA != "abc" {
+				t.Errorf("Expected %s merge of abc, got %s merge of %s", tt.wantMethod, gotMethod, gotSHA)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package gh
+
+import (
+	"context"
+	"sync"
+)
+
+// workerPool runs tasks with a bounded number of concurrent workers. Tasks may
+// schedule further tasks, and the first failing task cancels the shared context.
+type workerPool struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+}
+
+func newWorkerPool(ctx context.Context, size int) *workerPool {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &workerPool{
+		ctx:    ctx,
+		cancel: cancel,
+		sem:    make(chan struct{}, size),
+	}
+}
+
+func (p *workerPool) Go(task func(ctx context.Context) error) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		select {
+		case p.sem <- struct{}{}:
+		case <-p.ctx.Done():
+			p.fail(p.ctx.Err())
+			return
+		}
+
+		err := task(p.ctx)
+		<-p.sem
+
+		if err != nil {
+			p.fail(err)
+		}
+	}()
+}
+
+// Wait blocks until all tasks, including the ones scheduled by other tasks, are done
+// and returns the first error encountered.
+func (p *workerPool) Wait() error {
+	p.wg.Wait()
+	p.cancel()
+
+	return p.err
+}
+
+func (p *workerPool) fail(err error) {
+	p.once.Do(func() {
+		p.err = err
+		p.cancel()
+	})
+}
@@ -0,0 +1,63 @@
+package gh
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkerPoolBoundsConcurrency(t *testing.T) {
+	pool := newWorkerPool(context.Background(), 2)
+
+	var running, maxRunning atomic.Int32
+	for range 3 {
+		pool.Go(func(ctx context.Context) error {
+			for range 3 {
+				pool.Go(func(ctx context.Context) error {
+					n := running.Add(1)
+					defer running.Add(-1)
+
+					for {
+						current := maxRunning.Load()
+						if n <= current || maxRunning.CompareAndSwap(current, n) {
+							break
+						}
+					}
+
+					time.Sleep(5 * time.Millisecond)
+					return nil
+				})
+			}
+			return nil
+		})
+	}
+
+	if err := pool.Wait(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if maxRunning.Load() > 2 {
+		t.Errorf("Expected at most 2 concurrent tasks, got %d", maxRunning.Load())
+	}
+}
+
+func TestWorkerPoolCancelsOnError(t *testing.T) {
+	pool := newWorkerPool(context.Background(), 2)
+	wantErr := errors.New("boom")
+
+	pool.Go(func(ctx context.Context) error {
+		// Only returns once the failing task cancelled the shared context
+		<-ctx.Done()
+		return nil
+	})
+
+	pool.Go(func(ctx context.Context) error {
+		return wantErr
+	})
+
+	if err := pool.Wait(); !errors.Is(err, wantErr) {
+		t.Errorf("Expected error '%v', got '%v'", wantErr, err)
+	}
+}
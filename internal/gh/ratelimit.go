@@ -0,0 +1,217 @@
+package gh
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pippokairos/workflow-monitor/internal/config"
+	"github.com/pippokairos/workflow-monitor/internal/debug"
+)
+
+const (
+	maxRateLimitRetries = 3
+	maxRateLimitWait    = time.Minute
+)
+
+type RateLimit struct {
+	Resource  string
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// Known reports whether at least one response carried rate limit information.
+func (r RateLimit) Known() bool {
+	return r.Limit > 0
+}
+
+// rateLimitTransport records the rate limit headers of every GitHub response and
+// retries requests rejected by secondary rate limits, or by primary ones resetting soon.
+type rateLimitTransport struct {
+	base http.RoundTripper
+
+	mu    sync.Mutex
+	rates map[string]RateLimit
+}
+
+func newRateLimitTransport(base http.RoundTripper) *rateLimitTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &rateLimitTransport{
+		base:  base,
+		rates: make(map[string]RateLimit),
+	}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		t.record(resp)
+
+		wait, retry := retryDelay(resp, attempt)
+		if !retry {
+			return resp, nil
+		}
+
+		debug.Printf("GitHub rate limit hit on %s, retrying in %s (attempt %d)", req.URL.Path, wait, attempt+1)
+		resp.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		req, err = rewindRequest(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *rateLimitTransport) record(resp *http.Response) {
+	limit, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, _ := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	reset, _ := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+
+	resource := resp.Header.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = "core"
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rates[resource] = RateLimit{
+		Resource:  resource,
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Unix(reset, 0),
+	}
+}
+
+func (t *rateLimitTransport) rate(resource string) RateLimit {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.rates[resource]
+}
+
+// retryDelay decides whether a response was rejected by a rate limit worth waiting for.
+func retryDelay(resp *http.Response, attempt int) (time.Duration, bool) {
+	if attempt >= maxRateLimitRetries {
+		return 0, false
+	}
+
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		seconds, err := strconv.Atoi(retryAfter)
+		if err != nil {
+			return 0, false
+		}
+		wait := time.Duration(seconds) * time.Second
+		return wait, wait <= maxRateLimitWait
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		wait := time.Until(time.Unix(reset, 0)) + time.Second
+		return wait, wait <= maxRateLimitWait
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || isSecondaryRateLimit(resp) {
+		return time.Second << attempt, true
+	}
+
+	return 0, false
+}
+
+// isSecondaryRateLimit peeks at the response body, leaving it readable for the caller.
+func isSecondaryRateLimit(resp *http.Response) bool {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(string(body)), "secondary rate limit")
+}
+
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return next, nil
+	}
+
+	if req.GetBody == nil {
+		return nil, fmt.Errorf("cannot retry %s %s: request body is not replayable", req.Method, req.URL.Path)
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	next.Body = body
+
+	return next, nil
+}
+
+// RateLimit returns the last known quota for the API used to fetch open PRs.
+func (c *Client) RateLimit() RateLimit {
+	resource := "core"
+	if c.api == config.GitHubAPIGraphQL {
+		resource = "graphql"
+	}
+
+	return c.rateLimits.rate(resource)
+}
+
+// CheckRateLimitBudget returns an error when the remaining quota is clearly too low
+// for a full refresh, estimated from the size of the previous one.
+func (c *Client) CheckRateLimitBudget() error {
+	rate := c.RateLimit()
+	if !rate.Known() || time.Now().After(rate.Reset) {
+		return nil
+	}
+
+	needed := c.estimateRequests()
+	if rate.Remaining < needed {
+		return fmt.Errorf("GitHub rate limit too low for a refresh: %d requests left, about %d needed, resets at %s",
+			rate.Remaining, needed, rate.Reset.Format("15:04"))
+	}
+
+	return nil
+}
+
+func (c *Client) estimateRequests() int {
+	if c.api == config.GitHubAPIGraphQL {
+		return (len(c.repos) + graphQLReposPerQuery - 1) / graphQLReposPerQuery
+	}
+
+	// One listing per repo plus one review listing per open PR
+	return len(c.repos) + int(c.lastOpenPRCount.Load())
+}
@@ -0,0 +1,113 @@
+package gh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTransportRetriesSecondaryLimit(t *testing.T) {
+	attempts := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message":"You have exceeded a secondary rate limit."}`)
+			return
+		}
+
+		w.Header().Set("X-RateLimit-Limit", "30")
+		w.Header().Set("X-RateLimit-Remaining", "29")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+		w.Header().Set("X-RateLimit-Resource", "search")
+		fmt.Fprint(w, `{"items":[]}`)
+	})
+
+	if _, err := client.FetchPRsNeedingMyReview(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+
+	rate := client.rateLimits.rate("search")
+	if rate.Limit != 30 || rate.Remaining != 29 {
+		t.Errorf("Unexpected recorded rate limit: %+v", rate)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := map[string]struct {
+		status    int
+		header    http.Header
+		attempt   int
+		wantRetry bool
+	}{
+		"success": {
+			status: http.StatusOK,
+			header: http.Header{},
+		},
+		"retry after within limit": {
+			status:    http.StatusForbidden,
+			header:    http.Header{"Retry-After": {"5"}},
+			wantRetry: true,
+		},
+		"retry after too long": {
+			status: http.StatusForbidden,
+			header: http.Header{"Retry-After": {"3600"}},
+		},
+		"primary limit resetting later": {
+			status: http.StatusForbidden,
+			header: http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)},
+			},
+		},
+		"too many requests": {
+			status:    http.StatusTooManyRequests,
+			header:    http.Header{},
+			wantRetry: true,
+		},
+		"retries exhausted": {
+			status:  http.StatusTooManyRequests,
+			header:  http.Header{},
+			attempt: maxRateLimitRetries,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: tt.header, Body: http.NoBody}
+
+			_, retry := retryDelay(resp, tt.attempt)
+			if retry != tt.wantRetry {
+				t.Errorf("Expected retry %v, got %v", tt.wantRetry, retry)
+			}
+		})
+	}
+}
+
+func TestCheckRateLimitBudget(t *testing.T) {
+	client := newTestClient(t, http.NotFound)
+	client.lastOpenPRCount.Store(10)
+
+	client.rateLimits.rates["core"] = RateLimit{Limit: 5000, Remaining: 5, Reset: time.Now().Add(time.Hour)}
+	if err := client.CheckRateLimitBudget(); err == nil {
+		t.Error("Expected error but got none")
+	}
+
+	client.rateLimits.rates["core"] = RateLimit{Limit: 5000, Remaining: 500, Reset: time.Now().Add(time.Hour)}
+	if err := client.CheckRateLimitBudget(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	client.rateLimits.rates["core"] = RateLimit{Limit: 5000, Remaining: 0, Reset: time.Now().Add(-time.Minute)}
+	if err := client.CheckRateLimitBudget(); err != nil {
+		t.Errorf("Unexpected error after reset: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package gh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v79/github"
+	"github.com/pippokairos/workflow-monitor/internal/debug"
+)
+
+const (
+	ReviewApprove        = "APPROVE"
+	ReviewComment        = "COMMENT"
+	ReviewRequestChanges = "REQUEST_CHANGES"
+)
+
+// SubmitReview submits a review with the given event. GitHub requires a body for
+// comments and change requests, but not for approvals.
+func (c *Client) SubmitReview(ctx context.Context, pr PullRequest, event, body string) error {
+	owner, repo, err := getOwnerAndRepo(pr.Repo)
+	if err != nil {
+		return err
+	}
+
+	switch event {
+	case ReviewApprove:
+	case ReviewComment, ReviewRequestChanges:
+		if strings.TrimSpace(body) == "" {
+			return fmt.Errorf("a %s review needs a body", strings.ToLower(strings.ReplaceAll(event, "_", " ")))
+		}
+	default:
+		return fmt.Errorf("invalid review event: %s", event)
+	}
+
+	review := &github.PullRequestReviewRequest{Event: github.Ptr(event)}
+	if body != "" {
+		review.Body = github.Ptr(body)
+	}
+
+	_, resp, err := c.github.PullRequests.CreateReview(ctx, owner, repo, pr.Number, review)
+	debug.Printf("GitHub PullRequests CreateReview response: %+v", resp)
+	if err != nil {
+		return fmt.Errorf("failed to submit review for PR #%d: %w", pr.Number, err)
+	}
+
+	return nil
+}
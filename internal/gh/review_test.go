@@ -0,0 +1,64 @@
+package gh
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestSubmitReview(t *testing.T) {
+	tests := map[string]struct {
+		event   string
+		body    string
+		wantErr bool
+	}{
+		"approve without body": {
+			event: ReviewApprove,
+		},
+		"comment with body": {
+			event: ReviewComment,
+			body:  "Looks good",
+		},
+		"request changes without body": {
+			event:   ReviewRequestChanges,
+			wantErr: true,
+		},
+		"invalid event": {
+			event:   "MERGE",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got map[string]string
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/repos/owner/repo/pulls/1/reviews" {
+					http.NotFound(w, r)
+					return
+				}
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.Write([]byte(`{}`))
+			})
+
+			err := client.SubmitReview(context.Background(), PullRequest{Repo: "owner/repo", Number: 1}, tt.event, tt.body)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got["event"] != tt.event || got["body"] != tt.body {
+				t.Errorf("Unexpected review payload: %v", got)
+			}
+		})
+	}
+}
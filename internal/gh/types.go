@@ -15,6 +15,9 @@ type PullRequest struct {
 	Author     string
 	Repo       string
 	Approvers  []string
+
+	RequestedReviewers []string
+	ChecksState        string // Only populated by the GraphQL backend
 }
 
 func ToInternalPullRequest(pr *github.PullRequest, approvers []string) *PullRequest {
@@ -23,6 +26,14 @@ func ToInternalPullRequest(pr *github.PullRequest, approvers []string) *PullRequ
 		author = *pr.User.Login
 	}
 
+	var requestedReviewers []string
+	for _, reviewer := range pr.RequestedReviewers {
+		requestedReviewers = append(requestedReviewers, reviewer.GetLogin())
+	}
+	for _, team := range pr.RequestedTeams {
+		requestedReviewers = append(requestedReviewers, team.GetSlug())
+	}
+
 	return &PullRequest{
 		URL:        pr.GetHTMLURL(),
 		Number:     pr.GetNumber(),
@@ -32,6 +43,8 @@ func ToInternalPullRequest(pr *github.PullRequest, approvers []string) *PullRequ
 		Author:     author,
 		Repo:       pr.GetBase().GetRepo().GetFullName(),
 		Approvers:  approvers,
+
+		RequestedReviewers: requestedReviewers,
 	}
 }
 
@@ -44,8 +57,11 @@ func ToPullRequest(issue *github.Issue) *PullRequest {
 		title = *issue.Title
 	}
 	if issue.RepositoryURL != nil {
+		// e.g. https://api.github.com/repos/owner/repo
 		parts := strings.Split(*issue.RepositoryURL, "/")
-		repo = parts[len(parts)-1]
+		if len(parts) >= 2 {
+			repo = strings.Join(parts[len(parts)-2:], "/")
+		}
 	}
 
 	return &PullRequest{
@@ -0,0 +1,183 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pippokairos/workflow-monitor/internal/analyzer"
+	"github.com/pippokairos/workflow-monitor/internal/gh"
+)
+
+const (
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+	FormatCSV      = "csv"
+)
+
+var Formats = []string{FormatJSON, FormatMarkdown, FormatCSV}
+
+// Categories, as they appear in the CSV output.
+const (
+	CategoryDoneNotMerged = "done_not_merged"
+	CategoryNeedReview    = "need_review"
+	CategoryReadyForQA    = "ready_for_qa"
+)
+
+// Report is the schema of the JSON output. Fields are only ever added to it, so
+// scripts can rely on the existing ones.
+type Report struct {
+	GeneratedAt   time.Time `json:"generated_at"`
+	DoneNotMerged []Item    `json:"done_not_merged"`
+	NeedReview    []Item    `json:"need_review"`
+	ReadyForQA    []Item    `json:"ready_for_qa"`
+}
+
+type Item struct {
+	TicketKey string   `json:"ticket_key"` // Empty for PRs needing review
+	Repo      string   `json:"repo"`
+	Number    int      `json:"number"`
+	Title     string   `json:"title"`
+	URL       string   `json:"url"`
+	Author    string   `json:"author"`
+	Approvers []string `json:"approvers"`
+}
+
+func New(insights *analyzer.Insights, generatedAt time.Time) *Report {
+	r := &Report{
+		GeneratedAt:   generatedAt.UTC(),
+		DoneNotMerged: make([]Item, 0, len(insights.DoneNotMergedPRs)),
+		NeedReview:    make([]Item, 0, len(insights.NeedReviewPRs)),
+		ReadyForQA:    make([]Item, 0, len(insights.ReviewedNotInQAPRs)),
+	}
+
+	for _, item := range insights.DoneNotMergedPRs {
+		r.DoneNotMerged = append(r.DoneNotMerged, newItem(item.IssueID, item.PullRequest))
+	}
+	for _, pr := range insights.NeedReviewPRs {
+		r.NeedReview = append(r.NeedReview, newItem("", gh.PullRequest(pr)))
+	}
+	for _, item := range insights.ReviewedNotInQAPRs {
+		r.ReadyForQA = append(r.ReadyForQA, newItem(item.IssueID, item.PullRequest))
+	}
+
+	return r
+}
+
+func newItem(ticketKey string, pr gh.PullRequest) Item {
+	approvers := pr.Approvers
+	if approvers == nil {
+		approvers = []string{}
+	}
+
+	return Item{
+		TicketKey: ticketKey,
+		Repo:      pr.Repo,
+		Number:    pr.Number,
+		Title:     pr.Title,
+		URL:       pr.URL,
+		Author:    pr.Author,
+		Approvers: approvers,
+	}
+}
+
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatJSON:
+		return r.writeJSON(w)
+	case FormatMarkdown:
+		return r.writeMarkdown(w)
+	case FormatCSV:
+		return r.writeCSV(w)
+	default:
+		return fmt.Errorf("unknown report format: %s (expected: json, markdown or csv)", format)
+	}
+}
+
+func (r *Report) writeJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func (r *Report) writeMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Workflow report (%s)\n", r.GeneratedAt.Format("2006-01-02 15:04 MST"))
+
+	sections := []struct {
+		title string
+		items []Item
+	}{
+		{"Ticket done, PRs not merged", r.DoneNotMerged},
+		{"Need review", r.NeedReview},
+		{"Ready for QA", r.ReadyForQA},
+	}
+	for _, section := range sections {
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", section.title, len(section.items))
+		if len(section.items) == 0 {
+			b.WriteString("Nothing here.\n")
+			continue
+		}
+
+		for _, item := range section.items {
+			b.WriteString("- ")
+			if item.TicketKey != "" {
+				b.WriteString(item.TicketKey + ": ")
+			}
+			fmt.Fprintf(&b, "[%s#%d %s](%s) by %s", item.Repo, item.Number, escapeMarkdown(item.Title), item.URL, item.Author)
+			if len(item.Approvers) > 0 {
+				fmt.Fprintf(&b, ", approved by %s", strings.Join(item.Approvers, ", "))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeMarkdown keeps brackets in titles from breaking the links.
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer(`[`, `\[`, `]`, `\]`).Replace(s)
+}
+
+// writeCSV writes one row per item, approvers being separated by semicolons.
+func (r *Report) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"category", "ticket_key", "repo", "number", "title", "url", "author", "approvers"}); err != nil {
+		return err
+	}
+
+	categories := []struct {
+		name  string
+		items []Item
+	}{
+		{CategoryDoneNotMerged, r.DoneNotMerged},
+		{CategoryNeedReview, r.NeedReview},
+		{CategoryReadyForQA, r.ReadyForQA},
+	}
+	for _, category := range categories {
+		for _, item := range category.items {
+			record := []string{
+				category.name,
+				item.TicketKey,
+				item.Repo,
+				strconv.Itoa(item.Number),
+				item.Title,
+				item.URL,
+				item.Author,
+				strings.Join(item.Approvers, ";"),
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,125 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pippokairos/workflow-monitor/internal/analyzer"
+	"github.com/pippokairos/workflow-monitor/internal/gh"
+)
+
+func testInsights() *analyzer.Insights {
+	return &analyzer.Insights{
+		DoneNotMergedPRs: []analyzer.DoneNotMergedPR{
+			{
+				IssueID: "PROJ-1",
+				PullRequest: gh.PullRequest{
+					URL:    "https://github.com/owner/repo/pull/1",
+					Number: 1,
+					Title:  "Add login [WIP]",
+					Author: "me",
+					Repo:   "owner/repo",
+				},
+			},
+		},
+		NeedReviewPRs: []analyzer.ReviewNeededPR{
+			{
+				URL:    "https://github.com/owner/web/pull/7",
+				Number: 7,
+				Title:  "Fix header, footer",
+				Author: "alice",
+				Repo:   "owner/web",
+			},
+		},
+	}
+}
+
+func TestWrite(t *testing.T) {
+	generatedAt := time.Date(2026, 1, 2, 9, 30, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		format  string
+		want    string
+		wantErr bool
+	}{
+		"json": {
+			format: FormatJSON,
+			want: `{
+  "generated_at": "2026-01-02T09:30:00Z",
+  "done_not_merged": [
+    {
+      "ticket_key": "PROJ-1",
+      "repo": "owner/repo",
+      "number": 1,
+      "title": "Add login [WIP]",
+      "url": "https://github.com/owner/repo/pull/1",
+      "author": "me",
+      "approvers": []
+    }
+  ],
+  "need_review": [
+    {
+      "ticket_key": "",
+      "repo": "owner/web",
+      "number": 7,
+      "title": "Fix header, footer",
+      "url": "https://github.com/owner/web/pull/7",
+      "author": "alice",
+      "approvers": []
+    }
+  ],
+  "ready_for_qa": []
+}
+`,
+		},
+		"markdown": {
+			format: FormatMarkdown,
+			want: `# Workflow report (2026-01-02 09:30 UTC)
+
+## Ticket done, PRs not merged (1)
+
+- PROJ-1: [owner/repo#1 Add login \[WIP\]](https://github.com/owner/repo/pull/1) by me
+
+## Need review (1)
+
+- [owner/web#7 Fix header, footer](https://github.com/owner/web/pull/7) by alice
+
+## Ready for QA (0)
+
+Nothing here.
+`,
+		},
+		"csv": {
+			format: FormatCSV,
+			want: `category,ticket_key,repo,number,title,url,author,approvers
+done_not_merged,PROJ-1,owner/repo,1,Add login [WIP],https://github.com/owner/repo/pull/1,me,
+need_review,,owner/web,7,"Fix header, footer",https://github.com/owner/web/pull/7,alice,
+`,
+		},
+		"unknown format": {
+			format:  "xml",
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var b strings.Builder
+			err := New(testInsights(), generatedAt).Write(&b, tt.format)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if b.String() != tt.want {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.want, b.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const autoRefreshTick = time.Second
+
+// autoRefreshTickMsg counts down to the next automatic refresh, also updating the
+// countdown in the footer.
+type autoRefreshTickMsg struct{}
+
+func autoRefreshTickCmd() tea.Cmd {
+	return tea.Tick(autoRefreshTick, func(time.Time) tea.Msg {
+		return autoRefreshTickMsg{}
+	})
+}
+
+func (m model) handleAutoRefreshTick() (tea.Model, tea.Cmd) {
+	// The countdown restarts once the fetch in flight completes, and stands still
+	// while nobody is looking at the terminal
+	if m.state == stateLoading || m.refreshing || !m.focused {
+		return m, autoRefreshTickCmd()
+	}
+
+	m.untilRefresh -= autoRefreshTick
+	if m.untilRefresh > 0 {
+		return m, autoRefreshTickCmd()
+	}
+
+	m.untilRefresh = m.refreshInterval
+	refreshed, cmd := m.refresh()
+	return refreshed, tea.Batch(cmd, autoRefreshTickCmd())
+}
+
+func (m model) renderAutoRefresh() string {
+	switch {
+	case m.refreshInterval == 0:
+		return ""
+	case !m.focused:
+		return " | auto-refresh paused"
+	case m.state == stateLoading || m.refreshing:
+		return ""
+	default:
+		return fmt.Sprintf(" | auto-refresh in %s", m.untilRefresh.Round(time.Second))
+	}
+}
@@ -7,22 +7,89 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pippokairos/workflow-monitor/internal/atlassian"
 	"github.com/pippokairos/workflow-monitor/internal/data"
+	"github.com/pippokairos/workflow-monitor/internal/gh"
 )
 
-func fetchDataCmd(fetcher *data.Fetcher, startTime time.Time) tea.Cmd {
+func fetchDataCmd(fetcher *data.Fetcher, startTime time.Time, generation int) tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
 		insights, err := fetcher.FetchAll(ctx)
 		duration := time.Since(startTime)
 		return fetchCompleteMsg{
+			generation: generation,
+			insights:   insights,
+			err:        err,
+			duration:   duration,
+			rateLimit:  fetcher.GitHubRateLimit(),
+		}
+	}
+}
+
+func fetchTransitionsCmd(fetcher *data.Fetcher, issueID string) tea.Cmd {
+	return func() tea.Msg {
+		transitions, err := fetcher.FetchTransitions(context.Background(), issueID)
+		return transitionsLoadedMsg{
+			issueID:     issueID,
+			transitions: transitions,
+			err:         err,
+		}
+	}
+}
+
+func transitionIssueCmd(fetcher *data.Fetcher, issueID string, transition atlassian.Transition) tea.Cmd {
+	return func() tea.Msg {
+		insights, err := fetcher.TransitionIssue(context.Background(), issueID, transition.ID)
+		return transitionDoneMsg{
+			issueID:    issueID,
+			transition: transition,
+			insights:   insights,
+			err:        err,
+		}
+	}
+}
+
+func mergePRCmd(fetcher *data.Fetcher, pr gh.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		insights, err := fetcher.MergePullRequest(context.Background(), pr)
+		return prMergedMsg{
+			pr:       pr,
 			insights: insights,
 			err:      err,
-			duration: duration,
 		}
 	}
 }
 
+func submitReviewCmd(fetcher *data.Fetcher, pr gh.PullRequest, event, body string) tea.Cmd {
+	return func() tea.Msg {
+		insights, err := fetcher.SubmitReview(context.Background(), pr, event, body)
+		return reviewSubmittedMsg{
+			pr:       pr,
+			event:    event,
+			insights: insights,
+			err:      err,
+		}
+	}
+}
+
+func fetchDetailsCmd(fetcher *data.Fetcher, generation int, issueID string, pr gh.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		msg := detailsLoadedMsg{generation: generation, url: pr.URL}
+
+		if issueID != "" {
+			msg.issue, msg.err = fetcher.FetchIssueDetails(ctx, issueID)
+			if msg.err != nil {
+				return msg
+			}
+		}
+
+		msg.pr, msg.err = fetcher.FetchPRDetails(ctx, pr)
+		return msg
+	}
+}
+
 func openURLCmd(url string) tea.Cmd {
 	return func() tea.Msg {
 		var cmd *exec.Cmd
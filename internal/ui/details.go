@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pippokairos/workflow-monitor/internal/atlassian"
+	"github.com/pippokairos/workflow-monitor/internal/gh"
+)
+
+const (
+	detailsWidth    = 60
+	detailsMaxLines = 8 // Per description and PR body
+)
+
+var detailsStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(subtleColor).
+	Padding(0, 1).
+	MarginLeft(2).
+	Width(detailsWidth)
+
+// detailsEntry caches the details of an item, keyed by its PR URL, until the next refresh.
+type detailsEntry struct {
+	loading bool
+	issue   *atlassian.IssueDetails // Nil for PRs without a ticket
+	pr      *gh.PRDetails
+	err     error
+}
+
+type detailsLoadedMsg struct {
+	generation int
+	url        string
+	issue      *atlassian.IssueDetails
+	pr         *gh.PRDetails
+	err        error
+}
+
+func (m model) toggleDetails() (tea.Model, tea.Cmd) {
+	m.showDetails = !m.showDetails
+	return m, m.loadDetails()
+}
+
+// loadDetails fetches the details of the selected item, unless the pane is hidden
+// or they're already cached.
+func (m model) loadDetails() tea.Cmd {
+	if !m.showDetails {
+		return nil
+	}
+
+	issueID, pr, ok := m.getSelectedItem()
+	if !ok {
+		return nil
+	}
+
+	if _, ok := m.details[pr.URL]; ok {
+		return nil
+	}
+
+	m.details[pr.URL] = &detailsEntry{loading: true}
+	return fetchDetailsCmd(m.fetcher, m.generation, issueID, pr)
+}
+
+func (m model) handleDetailsLoaded(msg detailsLoadedMsg) (tea.Model, tea.Cmd) {
+	// Details requested before a refresh may be outdated
+	if msg.generation != m.generation {
+		return m, nil
+	}
+
+	m.details[msg.url] = &detailsEntry{
+		issue: msg.issue,
+		pr:    msg.pr,
+		err:   msg.err,
+	}
+	return m, nil
+}
+
+// forgetIssueDetails drops the cached details of the items linked to the issue.
+func (m model) forgetIssueDetails(issueID string) {
+	for url, entry := range m.details {
+		if entry.issue != nil && entry.issue.Key == issueID {
+			delete(m.details, url)
+		}
+	}
+}
+
+func (m model) getSelectedItem() (string, gh.PullRequest, bool) {
+	i, ok := m.selectedIndex()
+	if !ok {
+		return "", gh.PullRequest{}, false
+	}
+
+	issueID, pr := viewItem(m.insights, m.selectedView, i)
+	return issueID, pr, true
+}
+
+func (m model) renderDetails() string {
+	_, pr, ok := m.getSelectedItem()
+	if !ok {
+		return ""
+	}
+
+	entry := m.details[pr.URL]
+	switch {
+	case entry == nil || entry.loading:
+		return detailsStyle.Render(m.spinner.View() + " Loading details...")
+	case entry.err != nil:
+		return detailsStyle.Render(noticeStyle.Render(entry.err.Error()))
+	}
+
+	var s string
+	if issue := entry.issue; issue != nil {
+		s += titleStyle.Render(fmt.Sprintf("%s %s", issue.Key, issue.Summary)) + "\n"
+		s += renderDetailsField("Status", issue.Status)
+		s += renderDetailsField("Assignee", issue.Assignee)
+		s += renderDetailsField("Priority", issue.Priority)
+		s += "\n" + truncateLines(issue.Description, detailsMaxLines) + "\n\n"
+	}
+
+	details := entry.pr
+	s += titleStyle.Render(fmt.Sprintf("%s #%d %s", pr.Repo, pr.Number, pr.Title)) + "\n"
+	s += renderDetailsField("Branch", fmt.Sprintf("%s → %s", details.Branch, details.Base))
+	s += renderDetailsField("CI", details.ChecksState)
+
+	reviews := make([]string, len(details.Reviews))
+	for i, review := range details.Reviews {
+		reviews[i] = fmt.Sprintf("%s (%s)", review.Author, strings.ToLower(strings.ReplaceAll(review.State, "_", " ")))
+	}
+	s += renderDetailsField("Reviews", strings.Join(reviews, ", "))
+	s += renderDetailsField("Requested", strings.Join(details.RequestedReviewers, ", "))
+	s += "\n" + truncateLines(details.Body, detailsMaxLines)
+
+	return detailsStyle.Render(strings.TrimRight(s, "\n"))
+}
+
+func renderDetailsField(name, value string) string {
+	if value == "" {
+		value = "-"
+	}
+
+	return subtitleStyle.Render(name+": ") + value + "\n"
+}
+
+func truncateLines(text string, maxLines int) string {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+	if text == "" {
+		return subtitleStyle.Render("No description")
+	}
+
+	lines := strings.Split(text, "\n")
+	if len(lines) > maxLines {
+		lines = append(lines[:maxLines], subtitleStyle.Render("…"))
+	}
+
+	return strings.Join(lines, "\n")
+}
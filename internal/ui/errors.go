@@ -0,0 +1,195 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	retryBaseDelay = 5 * time.Second
+	retryMaxDelay  = 5 * time.Minute
+)
+
+var bannerStyle = lipgloss.NewStyle().
+	Foreground(errorColor).
+	Border(lipgloss.NormalBorder(), false, false, false, true).
+	BorderForeground(errorColor).
+	PaddingLeft(1).
+	MarginLeft(2)
+
+// retryMsg triggers the automatic retry scheduled at the given time, unless a
+// fetch has been started or scheduled since.
+type retryMsg struct {
+	at time.Time
+}
+
+// handleFetchError keeps the last good data, if any, and schedules a retry with
+// exponential backoff.
+func (m model) handleFetchError(err error) (tea.Model, tea.Cmd) {
+	m.err = err
+	m.errDismissed = false
+	m.state = stateReady
+	if m.insights == nil {
+		m.state = stateError
+	}
+
+	delay := retryDelay(m.retryAttempt)
+	m.retryAttempt++
+	at := time.Now().Add(delay)
+	m.retryAt = at
+
+	return m, tea.Tick(delay, func(time.Time) tea.Msg {
+		return retryMsg{at: at}
+	})
+}
+
+func retryDelay(attempt int) time.Duration {
+	delay := retryBaseDelay
+	for range attempt {
+		delay *= 2
+		if delay >= retryMaxDelay {
+			return retryMaxDelay
+		}
+	}
+
+	return delay
+}
+
+func (m model) handleRetry(msg retryMsg) (tea.Model, tea.Cmd) {
+	if m.state == stateLoading || !msg.at.Equal(m.retryAt) {
+		return m, nil
+	}
+
+	return m.refresh()
+}
+
+// refresh refetches everything, unless it's already being refetched or it would
+// clearly run out of GitHub quota.
+func (m model) refresh() (tea.Model, tea.Cmd) {
+	if m.refreshing {
+		return m, nil
+	}
+
+	if err := m.fetcher.CheckGitHubRateLimitBudget(); err != nil {
+		return m.handleFetchError(err)
+	}
+
+	// The previous data stays on screen while it's refreshed
+	if m.insights == nil {
+		m.state = stateLoading
+	} else {
+		m.refreshing = true
+	}
+	m.retryAt = time.Time{}
+	m.startTime = time.Now()
+	return m, tea.Batch(
+		m.spinner.Tick,
+		fetchDataCmd(m.fetcher, m.startTime, m.fetchGeneration),
+	)
+}
+
+// updateFetchError handles the keys available when there's no data to show
+// because the first fetch failed.
+func (m model) updateFetchError(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.mode == modeErrorDetails {
+		return m.updateErrorDetails(msg)
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+	case key.Matches(msg, m.keys.Refresh):
+		return m.refresh()
+	case key.Matches(msg, m.keys.ErrorDetails):
+		m.mode = modeErrorDetails
+	}
+
+	return m, nil
+}
+
+func (m model) updateErrorDetails(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "esc", msg.String() == "q", key.Matches(msg, m.keys.ErrorDetails):
+		m.mode = modeNormal
+	case msg.String() == "ctrl+c":
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+func (m model) renderFetchError() string {
+	if m.mode == modeErrorDetails {
+		return "\n" + m.renderErrorDetails()
+	}
+
+	errorMsg := lipgloss.NewStyle().
+		Foreground(errorColor).
+		Bold(true).
+		Render(fmt.Sprintf("Error: %v", m.err))
+	help := fmt.Sprintf("Retrying at %s | %s: retry now | %s: error details | %s: quit", m.retryAt.Format("15:04:05"),
+		m.keys.Refresh.Help().Key, m.keys.ErrorDetails.Help().Key, m.keys.Quit.Help().Key)
+
+	return fmt.Sprintf("\n%s\n\n%s\n", errorMsg, subtitleStyle.Render(help))
+}
+
+// renderBanner shows the error of the last refresh above the data it couldn't update.
+func (m model) renderBanner() string {
+	if m.err == nil || m.errDismissed {
+		return ""
+	}
+
+	help := fmt.Sprintf("Retrying at %s | %s: retry now | %s: details | %s: dismiss", m.retryAt.Format("15:04:05"),
+		m.keys.Refresh.Help().Key, m.keys.ErrorDetails.Help().Key, m.keys.DismissError.Help().Key)
+	banner := truncate("Refresh failed, showing data loaded at "+m.loadedAt.Format("15:04:05"), m.width-5) + "\n" +
+		truncate(firstLine(m.err.Error()), m.width-5) + "\n" +
+		subtitleStyle.Render(truncate(help, m.width-5))
+
+	return bannerStyle.Render(banner) + "\n\n"
+}
+
+func (m model) renderErrorDetails() string {
+	s := titleStyle.Render("Error details") + "\n\n"
+	for _, line := range errorChain(m.err, 0) {
+		s += line + "\n"
+	}
+	s += "\n" + subtitleStyle.Render("Esc: close")
+
+	style := popupStyle
+	if m.width > 0 {
+		style = style.Width(min(100, m.width-2))
+	}
+
+	return style.Render(s) + "\n\n"
+}
+
+// errorChain lists the messages of err and of the errors it wraps, indenting
+// wrapped errors below the ones wrapping them.
+func errorChain(err error, depth int) []string {
+	if err == nil {
+		return nil
+	}
+
+	indent := strings.Repeat("  ", depth)
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var lines []string
+		for _, e := range joined.Unwrap() {
+			lines = append(lines, errorChain(e, depth)...)
+		}
+		return lines
+	}
+
+	lines := []string{indent + "• " + err.Error()}
+	return append(lines, errorChain(errors.Unwrap(err), depth+1)...)
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
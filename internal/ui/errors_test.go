@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pippokairos/workflow-monitor/internal/analyzer"
+	"github.com/pippokairos/workflow-monitor/internal/config"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := map[string]struct {
+		attempt int
+		want    time.Duration
+	}{
+		"first retry":          {attempt: 0, want: 5 * time.Second},
+		"second retry":         {attempt: 1, want: 10 * time.Second},
+		"fourth retry":         {attempt: 3, want: 40 * time.Second},
+		"last before the cap":  {attempt: 5, want: 160 * time.Second},
+		"capped":               {attempt: 6, want: retryMaxDelay},
+		"far beyond the cap":   {attempt: 100, want: retryMaxDelay},
+		"negative is the base": {attempt: -1, want: retryBaseDelay},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := retryDelay(tt.attempt); got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestErrorChain(t *testing.T) {
+	base := errors.New("connection refused")
+
+	tests := map[string]struct {
+		err  error
+		want []string
+	}{
+		"nil": {
+			err:  nil,
+			want: nil,
+		},
+		"single error": {
+			err:  base,
+			want: []string{"• connection refused"},
+		},
+		"wrapped error": {
+			err: fmt.Errorf("failed to fetch PRs: %w", base),
+			want: []string{
+				"• failed to fetch PRs: connection refused",
+				"  • connection refused",
+			},
+		},
+		"joined errors": {
+			err: errors.Join(fmt.Errorf("jira: %w", base), errors.New("github: timeout")),
+			want: []string{
+				"• jira: connection refused",
+				"  • connection refused",
+				"• github: timeout",
+			},
+		},
+		"joined errors wrapped": {
+			err: fmt.Errorf("refresh failed: %w", errors.Join(base, errors.New("timeout"))),
+			want: []string{
+				"• refresh failed: connection refused\ntimeout",
+				"  • connection refused",
+				"  • timeout",
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := errorChain(tt.err, 0); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFetchErrorBanner(t *testing.T) {
+	var tm tea.Model = newTestModel(&analyzer.Insights{})
+	m := tm.(model)
+	m.info = "Merged owner/repo #1"
+	tm = m
+
+	// A failed refresh keeps the data, showing the error above it
+	tm, cmd := tm.Update(fetchCompleteMsg{err: errors.New("connection refused")})
+	m = tm.(model)
+	if m.state != stateReady {
+		t.Fatalf("Expected the data to stay on screen, got state %d", m.state)
+	}
+	if cmd == nil || m.retryAt.IsZero() || m.retryAttempt != 1 {
+		t.Errorf("Expected a retry to be scheduled, got retry at %s, attempt %d", m.retryAt, m.retryAttempt)
+	}
+	if !strings.Contains(m.renderBanner(), "connection refused") {
+		t.Errorf("Expected the banner to show the error, got %q", m.renderBanner())
+	}
+	if m.info != "Merged owner/repo #1" {
+		t.Errorf("Expected the action message to be kept, got %q", m.info)
+	}
+
+	tm, _ = tm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = tm.(model)
+	if m.renderBanner() != "" {
+		t.Errorf("Expected the banner to be dismissed, got %q", m.renderBanner())
+	}
+
+	// A retry scheduled before the last one is ignored
+	if _, cmd := tm.Update(retryMsg{at: m.retryAt.Add(-time.Second)}); cmd != nil {
+		t.Error("Expected an outdated retry to be ignored")
+	}
+
+	// The next failure shows the banner again, backing off further
+	firstRetryAt := m.retryAt
+	tm, _ = tm.Update(fetchCompleteMsg{err: errors.New("timeout")})
+	m = tm.(model)
+	if !strings.Contains(m.renderBanner(), "timeout") {
+		t.Errorf("Expected the banner to show the new error, got %q", m.renderBanner())
+	}
+	if m.retryAttempt != 2 || !m.retryAt.After(firstRetryAt) {
+		t.Errorf("Expected the retry to back off, got retry at %s, attempt %d", m.retryAt, m.retryAttempt)
+	}
+
+	tm, _ = tm.Update(fetchCompleteMsg{insights: &analyzer.Insights{}})
+	m = tm.(model)
+	if m.err != nil || m.renderBanner() != "" {
+		t.Errorf("Expected a successful fetch to clear the banner, got %q", m.renderBanner())
+	}
+	if m.retryAttempt != 0 || !m.retryAt.IsZero() {
+		t.Errorf("Expected the retry to be reset, got retry at %s, attempt %d", m.retryAt, m.retryAttempt)
+	}
+	if m.info != "Merged owner/repo #1" {
+		t.Errorf("Expected the action message to be kept, got %q", m.info)
+	}
+}
+
+func TestFirstFetchError(t *testing.T) {
+	var tm tea.Model = InitialModel(nil, &config.Config{})
+	tm, cmd := tm.Update(fetchCompleteMsg{err: fmt.Errorf("failed to fetch tickets: %w", errors.New("401 Unauthorized"))})
+	m := tm.(model)
+
+	if m.state != stateError {
+		t.Fatalf("Expected the error state without data to show, got state %d", m.state)
+	}
+	if cmd == nil {
+		t.Error("Expected a retry to be scheduled")
+	}
+	if !strings.Contains(m.View(), "failed to fetch tickets: 401 Unauthorized") {
+		t.Errorf("Expected the error to be shown, got %q", m.View())
+	}
+
+	tm, _ = tm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m = tm.(model)
+	if m.mode != modeErrorDetails || !strings.Contains(m.View(), "  • 401 Unauthorized") {
+		t.Errorf("Expected the error details, got %q", m.View())
+	}
+
+	tm, _ = tm.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if tm.(model).mode != modeNormal {
+		t.Errorf("Expected the error details to close, got mode %d", tm.(model).mode)
+	}
+}
@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pippokairos/workflow-monitor/internal/analyzer"
+	"github.com/pippokairos/workflow-monitor/internal/gh"
+)
+
+var matchStyle = lipgloss.NewStyle().
+	Foreground(secondaryColor).
+	Underline(true)
+
+// itemFields are the fields of a list item the filter is matched against.
+type itemFields struct {
+	key    string
+	title  string
+	author string
+	repo   string
+}
+
+// filteredItem is an item of the current view matching the filter, along with the
+// positions of the matched runes in each of its fields.
+type filteredItem struct {
+	index  int // In the view's insights
+	key    []int
+	title  []int
+	author []int
+	repo   []int
+}
+
+func newFilterInput() textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "ticket, title, author or repo"
+
+	return ti
+}
+
+func (m model) startFilter() (tea.Model, tea.Cmd) {
+	m.mode = modeFilter
+	return m, m.filterInput.Focus()
+}
+
+func (m model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = modeNormal
+		m.filterInput.Blur()
+		return m.applyFilter("")
+
+	case "enter":
+		// Keeps the filter, handing the keyboard back to the list
+		m.mode = modeNormal
+		m.filterInput.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	if strings.TrimSpace(m.filterInput.Value()) == m.filter {
+		return m, cmd
+	}
+
+	m, filterCmd := m.applyFilter(m.filterInput.Value())
+	return m, tea.Batch(cmd, filterCmd)
+}
+
+// applyFilter filters the views with the query, a blank one clearing the filter.
+func (m model) applyFilter(filter string) (model, tea.Cmd) {
+	m.filter = strings.TrimSpace(filter)
+	m.filterInput.SetValue(filter)
+	m.cursor = 0
+	m.offset = 0
+	return m, m.loadDetails()
+}
+
+func prFields(issueID string, pr gh.PullRequest) itemFields {
+	return itemFields{key: issueID, title: pr.Title, author: pr.Author, repo: pr.Repo}
+}
+
+// viewItem returns the i-th item of the view, with the key of its ticket if it has one.
+func viewItem(insights *analyzer.Insights, view, i int) (string, gh.PullRequest) {
+	switch view {
+	case 0:
+		item := insights.DoneNotMergedPRs[i]
+		return item.IssueID, item.PullRequest
+	case 1:
+		return "", gh.PullRequest(insights.NeedReviewPRs[i])
+	default:
+		item := insights.ReviewedNotInQAPRs[i]
+		return item.IssueID, item.PullRequest
+	}
+}
+
+func viewLen(insights *analyzer.Insights, view int) int {
+	switch view {
+	case 0:
+		return len(insights.DoneNotMergedPRs)
+	case 1:
+		return len(insights.NeedReviewPRs)
+	default:
+		return len(insights.ReviewedNotInQAPRs)
+	}
+}
+
+// filteredItems lists the items of the view matching the filter, all of them when
+// there's no filter.
+func (m model) filteredItems(view int) []filteredItem {
+	var items []filteredItem
+	for i := range viewLen(m.insights, view) {
+		fields := prFields(viewItem(m.insights, view, i))
+		if m.filter == "" {
+			items = append(items, filteredItem{index: i})
+			continue
+		}
+
+		item := filteredItem{
+			index:  i,
+			key:    fuzzyMatch(m.filter, fields.key),
+			title:  fuzzyMatch(m.filter, fields.title),
+			author: fuzzyMatch(m.filter, fields.author),
+			repo:   fuzzyMatch(m.filter, fields.repo),
+		}
+		if item.key != nil || item.title != nil || item.author != nil || item.repo != nil {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// selectedIndex is the index in the current view's insights of the item under the cursor.
+func (m model) selectedIndex() (int, bool) {
+	items := m.filteredItems(m.selectedView)
+	if m.cursor >= len(items) {
+		return 0, false
+	}
+
+	return items[m.cursor].index, true
+}
+
+// fuzzyMatch returns the positions of the query's runes found in order in text,
+// ignoring case, or nil when they aren't all found.
+func fuzzyMatch(query, text string) []int {
+	query = strings.TrimSpace(query)
+	if query == "" || text == "" {
+		return nil
+	}
+
+	queryRunes := []rune(strings.ToLower(query))
+	var positions []int
+	for i, r := range []rune(text) {
+		if unicode.ToLower(r) == queryRunes[len(positions)] {
+			positions = append(positions, i)
+			if len(positions) == len(queryRunes) {
+				return positions
+			}
+		}
+	}
+
+	return nil
+}
+
+// highlight renders text with style, except the runes at positions which are
+// rendered as a match.
+func highlight(text string, positions []int, style lipgloss.Style) string {
+	if len(positions) == 0 {
+		return style.Render(text)
+	}
+
+	matched := make(map[int]bool, len(positions))
+	for _, position := range positions {
+		matched[position] = true
+	}
+
+	var s, segment strings.Builder
+	segmentMatched := false
+	flush := func() {
+		if segment.Len() == 0 {
+			return
+		}
+		if segmentMatched {
+			s.WriteString(matchStyle.Render(segment.String()))
+		} else {
+			s.WriteString(style.Render(segment.String()))
+		}
+		segment.Reset()
+	}
+
+	for i, r := range []rune(text) {
+		if matched[i] != segmentMatched {
+			flush()
+			segmentMatched = matched[i]
+		}
+		segment.WriteRune(r)
+	}
+	flush()
+
+	return s.String()
+}
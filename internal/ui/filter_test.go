@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pippokairos/workflow-monitor/internal/analyzer"
+	"github.com/pippokairos/workflow-monitor/internal/gh"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := map[string]struct {
+		query string
+		text  string
+		want  []int
+	}{
+		"exact":                {query: "login", text: "login", want: []int{0, 1, 2, 3, 4}},
+		"substring":            {query: "log", text: "Add login", want: []int{4, 5, 6}},
+		"scattered":            {query: "alp", text: "Add login page", want: []int{0, 4, 10}},
+		"case insensitive":     {query: "PROJ", text: "proj-12", want: []int{0, 1, 2, 3}},
+		"first occurrences":    {query: "aa", text: "banana", want: []int{1, 3}},
+		"out of order":         {query: "gol", text: "login", want: nil},
+		"missing rune":         {query: "logz", text: "login", want: nil},
+		"longer than text":     {query: "logins", text: "login", want: nil},
+		"surrounding spaces":   {query: "  log ", text: "login", want: []int{0, 1, 2}},
+		"inner space":          {query: "a l", text: "Add login", want: []int{0, 3, 4}},
+		"blank query":          {query: "   ", text: "login", want: nil},
+		"empty text":           {query: "log", text: "", want: nil},
+		"multibyte runes":      {query: "éa", text: "café bar", want: []int{3, 6}},
+		"matching rune counts": {query: "é", text: "résumé", want: []int{1}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := fuzzyMatch(tt.query, tt.text); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected positions %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	// Brackets make the matched segments visible without a color profile
+	defaultMatchStyle := matchStyle
+	defer func() { matchStyle = defaultMatchStyle }()
+	matchStyle = lipgloss.NewStyle().Transform(func(s string) string { return "[" + s + "]" })
+	style := lipgloss.NewStyle()
+
+	tests := map[string]struct {
+		text      string
+		positions []int
+		want      string
+	}{
+		"no match":          {text: "login", positions: nil, want: "login"},
+		"prefix":            {text: "login", positions: []int{0, 1}, want: "[lo]gin"},
+		"suffix":            {text: "login", positions: []int{3, 4}, want: "log[in]"},
+		"whole text":        {text: "login", positions: []int{0, 1, 2, 3, 4}, want: "[login]"},
+		"scattered":         {text: "Add login page", positions: []int{0, 5, 10}, want: "[A]dd l[o]gin [p]age"},
+		"adjacent segments": {text: "abcd", positions: []int{0, 1, 3}, want: "[ab]c[d]"},
+		"multibyte runes":   {text: "café bar", positions: []int{3, 6}, want: "caf[é] b[a]r"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := highlight(tt.text, tt.positions, style); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestApplyFilter(t *testing.T) {
+	insights := &analyzer.Insights{
+		DoneNotMergedPRs: []analyzer.DoneNotMergedPR{
+			{IssueID: "PROJ-1", PullRequest: gh.PullRequest{Title: "Add login", Author: "me", Repo: "owner/web"}},
+		},
+		NeedReviewPRs: []analyzer.ReviewNeededPR{
+			{Title: "Fix header", Author: "alice", Repo: "owner/web"},
+			{Title: "Bump deps", Author: "bob", Repo: "owner/api"},
+		},
+	}
+
+	tests := map[string]struct {
+		query      string
+		wantFilter string
+		wantItems  []int
+	}{
+		"matching query":   {query: "alice", wantFilter: "alice", wantItems: []int{0, 1, 0}},
+		"trimmed query":    {query: " web ", wantFilter: "web", wantItems: []int{1, 1, 0}},
+		"whitespace query": {query: "   ", wantFilter: "", wantItems: []int{1, 2, 0}},
+		"empty query":      {query: "", wantFilter: "", wantItems: []int{1, 2, 0}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := newTestModel(insights)
+			m, _ = m.applyFilter(tt.query)
+
+			if m.filter != tt.wantFilter {
+				t.Errorf("Expected filter %q, got %q", tt.wantFilter, m.filter)
+			}
+			for view, want := range tt.wantItems {
+				if got := len(m.filteredItems(view)); got != want {
+					t.Errorf("Expected %d items in view %d, got %d", want, view, got)
+				}
+			}
+			if tt.wantFilter == "" && strings.Contains(m.renderHeader(), "/") {
+				t.Errorf("Expected the tabs without filtered counts, got %q", m.renderHeader())
+			}
+		})
+	}
+}
+
+func TestUpdateFilterTyping(t *testing.T) {
+	m := newTestModel(&analyzer.Insights{
+		NeedReviewPRs: []analyzer.ReviewNeededPR{{Title: "Fix header", Author: "alice", Repo: "owner/web"}},
+	})
+	m.selectedView = 1
+
+	var tm tea.Model = m
+	tm, _ = tm.(model).startFilter()
+	for _, r := range "fix he " {
+		tm, _ = tm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	m = tm.(model)
+	if m.filterInput.Value() != "fix he " {
+		t.Errorf("Expected the input to keep the typed space, got %q", m.filterInput.Value())
+	}
+	if m.filter != "fix he" {
+		t.Errorf("Expected filter %q, got %q", "fix he", m.filter)
+	}
+	if got := len(m.filteredItems(1)); got != 1 {
+		t.Errorf("Expected 1 item, got %d", got)
+	}
+}
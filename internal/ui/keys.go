@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pippokairos/workflow-monitor/internal/config"
+)
+
+// keyMap holds the configurable bindings of the list views. Pickers, confirmations
+// and the review composer keep fixed keys.
+type keyMap struct {
+	Quit         key.Binding
+	SwitchView   key.Binding
+	Up           key.Binding
+	Down         key.Binding
+	PageUp       key.Binding
+	PageDown     key.Binding
+	Top          key.Binding
+	Bottom       key.Binding
+	Open         key.Binding
+	Details      key.Binding
+	Filter       key.Binding
+	ClearFilter  key.Binding
+	Transition   key.Binding
+	Merge        key.Binding
+	Review       key.Binding
+	Refresh      key.Binding
+	ErrorDetails key.Binding
+	DismissError key.Binding
+	Help         key.Binding
+}
+
+// Names shown in the help for keys that aren't self-explanatory.
+var keyLabels = map[string]string{
+	"up":     "↑",
+	"down":   "↓",
+	"left":   "←",
+	"right":  "→",
+	"pgup":   "PgUp",
+	"pgdown": "PgDn",
+	"home":   "Home",
+	"end":    "End",
+	"enter":  "Enter",
+	"esc":    "Esc",
+	"tab":    "Tab",
+	" ":      "Space",
+}
+
+func newKeyMap(bindings map[string][]string) keyMap {
+	binding := func(action, desc string) key.Binding {
+		keys := bindings[action]
+		return key.NewBinding(key.WithKeys(keys...), key.WithHelp(keysLabel(keys), desc))
+	}
+
+	return keyMap{
+		Quit:         binding(config.ActionQuit, "quit"),
+		SwitchView:   binding(config.ActionSwitchView, "switch view"),
+		Up:           binding(config.ActionUp, "up"),
+		Down:         binding(config.ActionDown, "down"),
+		PageUp:       binding(config.ActionPageUp, "page up"),
+		PageDown:     binding(config.ActionPageDown, "page down"),
+		Top:          binding(config.ActionTop, "first item"),
+		Bottom:       binding(config.ActionBottom, "last item"),
+		Open:         binding(config.ActionOpen, "open in browser"),
+		Details:      binding(config.ActionDetails, "details"),
+		Filter:       binding(config.ActionFilter, "filter"),
+		ClearFilter:  binding(config.ActionClearFilter, "clear filter"),
+		Transition:   binding(config.ActionTransition, "transition ticket"),
+		Merge:        binding(config.ActionMerge, "merge PR"),
+		Review:       binding(config.ActionReview, "review PR"),
+		Refresh:      binding(config.ActionRefresh, "refresh"),
+		ErrorDetails: binding(config.ActionErrorDetails, "error details"),
+		DismissError: binding(config.ActionDismissError, "dismiss error"),
+		Help:         binding(config.ActionHelp, "all keys"),
+	}
+}
+
+func keysLabel(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		if label, ok := keyLabels[k]; ok {
+			labels[i] = label
+		} else {
+			labels[i] = k
+		}
+	}
+
+	return strings.Join(labels, "/")
+}
+
+func (k keyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.SwitchView, k.Up, k.Down, k.Open, k.Filter, k.Refresh, k.Help, k.Quit}
+}
+
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.PageUp, k.PageDown, k.Top, k.Bottom},
+		{k.SwitchView, k.Open, k.Details, k.Filter, k.ClearFilter},
+		{k.Transition, k.Merge, k.Review},
+		{k.Refresh, k.ErrorDetails, k.DismissError, k.Help, k.Quit},
+	}
+}
+
+func (m model) updateHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "esc", msg.String() == "q", key.Matches(msg, m.keys.Help):
+		m.mode = modeNormal
+	case msg.String() == "ctrl+c":
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+func (m model) renderHelp() string {
+	s := titleStyle.Render("Keys") + "\n\n"
+	s += m.help.FullHelpView(m.keys.FullHelp()) + "\n\n"
+	s += subtitleStyle.Render("Pickers and the review composer show their own keys | Esc: close")
+
+	return popupStyle.Render(s) + "\n\n"
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pippokairos/workflow-monitor/internal/analyzer"
+	"github.com/pippokairos/workflow-monitor/internal/gh"
+)
+
+type prMergedMsg struct {
+	pr       gh.PullRequest
+	insights *analyzer.Insights
+	err      error
+}
+
+func (m model) startMerge() (tea.Model, tea.Cmd) {
+	issueID, pr, ok := m.getSelectedItem()
+	if m.selectedView != 0 || !ok {
+		m.notice = "Select a PR in the \"Ticket done, PRs not merged\" view to merge it"
+		return m, nil
+	}
+
+	return m.askConfirmation(
+		fmt.Sprintf("Merge %s #%d (%s) for %s?", pr.Repo, pr.Number, pr.Title, issueID),
+		fmt.Sprintf("Merging %s #%d...", pr.Repo, pr.Number),
+		mergePRCmd(m.fetcher, pr),
+	)
+}
+
+func (m model) handlePRMerged(msg prMergedMsg) (tea.Model, tea.Cmd) {
+	m.info = ""
+	if msg.err != nil {
+		m.notice = msg.err.Error()
+		return m, nil
+	}
+
+	m.insights = msg.insights
+	m.fetchGeneration++
+	m.clampCursor()
+	m.info = fmt.Sprintf("Merged %s #%d", msg.pr.Repo, msg.pr.Number)
+	return m, m.loadDetails()
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pippokairos/workflow-monitor/internal/analyzer"
+	"github.com/pippokairos/workflow-monitor/internal/gh"
+)
+
+// Gone items listed below a view, the rest are only counted.
+const maxRemovedShown = 5
+
+var (
+	newBadgeStyle = lipgloss.NewStyle().
+			Foreground(tertiaryColor).
+			Background(secondaryColor).
+			Padding(0, 1)
+
+	removedStyle = lipgloss.NewStyle().
+			Foreground(subtleColor).
+			Strikethrough(true)
+)
+
+type removedItem struct {
+	issueID string
+	pr      gh.PullRequest
+}
+
+// trackChanges records, for each view, the items that appeared and disappeared
+// between the current insights and the refreshed ones.
+func (m *model) trackChanges(refreshed *analyzer.Insights) {
+	for view := range m.added {
+		previous := make(map[string]bool)
+		for i := range viewLen(m.insights, view) {
+			_, pr := viewItem(m.insights, view, i)
+			previous[pr.URL] = true
+		}
+
+		current := make(map[string]bool)
+		m.added[view] = make(map[string]bool)
+		for i := range viewLen(refreshed, view) {
+			_, pr := viewItem(refreshed, view, i)
+			current[pr.URL] = true
+			if !previous[pr.URL] {
+				m.added[view][pr.URL] = true
+			}
+		}
+
+		m.removed[view] = nil
+		for i := range viewLen(m.insights, view) {
+			issueID, pr := viewItem(m.insights, view, i)
+			if !current[pr.URL] {
+				m.removed[view] = append(m.removed[view], removedItem{issueID: issueID, pr: pr})
+			}
+		}
+	}
+}
+
+// restoreCursor moves the cursor back to the PR it was on before the refresh, or
+// keeps it in range when that PR is gone.
+func (m *model) restoreCursor(url string) {
+	for i, item := range m.filteredItems(m.selectedView) {
+		if _, pr := viewItem(m.insights, m.selectedView, item.index); url != "" && pr.URL == url {
+			m.cursor = i
+			m.scrollToCursor()
+			return
+		}
+	}
+
+	m.clampCursor()
+}
+
+func (m model) newBadge(view int, pr gh.PullRequest) string {
+	if !m.added[view][pr.URL] {
+		return ""
+	}
+
+	return " " + newBadgeStyle.Render("new")
+}
+
+// renderRemoved lists the items of the current view that disappeared with the last refresh.
+func (m model) renderRemoved() string {
+	removed := m.removed[m.selectedView]
+	if len(removed) == 0 {
+		return ""
+	}
+
+	s := subtitleStyle.Render("  Gone since the last refresh:") + "\n"
+	for i, item := range removed {
+		if i == maxRemovedShown {
+			s += subtitleStyle.Render(fmt.Sprintf("    and %d more", len(removed)-maxRemovedShown)) + "\n"
+			break
+		}
+
+		label := fmt.Sprintf("%s #%d %s", item.pr.Repo, item.pr.Number, item.pr.Title)
+		if item.issueID != "" {
+			label = item.issueID + " " + label
+		}
+		s += "    " + removedStyle.Render(truncate(label, m.width-4)) + "\n"
+	}
+
+	return s + "\n"
+}
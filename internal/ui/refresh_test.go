@@ -0,0 +1,207 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pippokairos/workflow-monitor/internal/analyzer"
+)
+
+// needReviewInsights returns insights with a PR needing review per number, its
+// URL ending with the number.
+func needReviewInsights(numbers ...int) *analyzer.Insights {
+	insights := &analyzer.Insights{}
+	for _, number := range numbers {
+		insights.NeedReviewPRs = append(insights.NeedReviewPRs, analyzer.ReviewNeededPR{
+			URL:    fmt.Sprintf("https://github.com/owner/repo/pull/%d", number),
+			Number: number,
+			Title:  fmt.Sprintf("PR %d", number),
+			Repo:   "owner/repo",
+		})
+	}
+	return insights
+}
+
+func TestTrackChanges(t *testing.T) {
+	tests := map[string]struct {
+		before      []int
+		after       []int
+		wantAdded   []int
+		wantRemoved []int
+	}{
+		"unchanged": {
+			before: []int{1, 2},
+			after:  []int{1, 2},
+		},
+		"added": {
+			before:    []int{1},
+			after:     []int{1, 2, 3},
+			wantAdded: []int{2, 3},
+		},
+		"removed": {
+			before:      []int{1, 2, 3},
+			after:       []int{2},
+			wantRemoved: []int{1, 3},
+		},
+		"added and removed": {
+			before:      []int{1, 2},
+			after:       []int{2, 3},
+			wantAdded:   []int{3},
+			wantRemoved: []int{1},
+		},
+		"reordered": {
+			before: []int{1, 2, 3},
+			after:  []int{3, 1, 2},
+		},
+		"everything gone": {
+			before:      []int{1, 2},
+			after:       nil,
+			wantRemoved: []int{1, 2},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := newTestModel(needReviewInsights(tt.before...))
+			m.trackChanges(needReviewInsights(tt.after...))
+
+			var added []int
+			for _, pr := range needReviewInsights(tt.after...).NeedReviewPRs {
+				if m.added[1][pr.URL] {
+					added = append(added, pr.Number)
+				}
+			}
+			if !slices.Equal(added, tt.wantAdded) {
+				t.Errorf("Expected added %v, got %v", tt.wantAdded, added)
+			}
+
+			var removed []int
+			for _, item := range m.removed[1] {
+				removed = append(removed, item.pr.Number)
+			}
+			if !slices.Equal(removed, tt.wantRemoved) {
+				t.Errorf("Expected removed %v, got %v", tt.wantRemoved, removed)
+			}
+
+			if len(m.added[0]) != 0 || len(m.removed[0]) != 0 || len(m.added[2]) != 0 || len(m.removed[2]) != 0 {
+				t.Errorf("Expected the other views to be unchanged, got added %v, removed %v", m.added, m.removed)
+			}
+		})
+	}
+}
+
+func TestRefreshHighlightsChanges(t *testing.T) {
+	var tm tea.Model = newTestModel(needReviewInsights(1, 2))
+	m := tm.(model)
+	m.selectedView = 1
+	tm = m
+
+	tm, _ = tm.Update(fetchCompleteMsg{insights: needReviewInsights(2, 3)})
+	view := ansi.Strip(tm.View())
+
+	if !strings.Contains(lineContaining(view, "#3 PR 3"), "new") {
+		t.Errorf("Expected the added PR to be marked as new, got %q", view)
+	}
+	if strings.Contains(lineContaining(view, "#2 PR 2"), "new") {
+		t.Errorf("Expected the kept PR not to be marked as new, got %q", view)
+	}
+	if !strings.Contains(view, "Gone since the last refresh:") || !strings.Contains(view, "owner/repo #1 PR 1") {
+		t.Errorf("Expected the removed PR to be listed, got %q", view)
+	}
+
+	// Changes are relative to the last refresh only
+	tm, _ = tm.Update(fetchCompleteMsg{insights: needReviewInsights(2, 3)})
+	view = ansi.Strip(tm.View())
+	if strings.Contains(view, "new") || strings.Contains(view, "Gone since the last refresh:") {
+		t.Errorf("Expected no changes after an identical refresh, got %q", view)
+	}
+}
+
+func lineContaining(s, substr string) string {
+	for line := range strings.Lines(s) {
+		if strings.Contains(line, substr) {
+			return line
+		}
+	}
+	return ""
+}
+
+func TestRestoreCursor(t *testing.T) {
+	tests := map[string]struct {
+		before     []int
+		cursor     int
+		filter     string
+		after      []int
+		wantCursor int
+	}{
+		"same position": {
+			before:     []int{1, 2, 3},
+			cursor:     1,
+			after:      []int{1, 2, 3},
+			wantCursor: 1,
+		},
+		"follows the PR down": {
+			before:     []int{1, 2, 3},
+			cursor:     1,
+			after:      []int{4, 5, 1, 2, 3},
+			wantCursor: 3,
+		},
+		"follows the PR up": {
+			before:     []int{1, 2, 3},
+			cursor:     2,
+			after:      []int{3},
+			wantCursor: 0,
+		},
+		"selected PR gone keeps the position": {
+			before:     []int{1, 2, 3},
+			cursor:     1,
+			after:      []int{1, 3},
+			wantCursor: 1,
+		},
+		"selected PR gone at the last index": {
+			before:     []int{1, 2, 3},
+			cursor:     2,
+			after:      []int{1, 2},
+			wantCursor: 1,
+		},
+		"everything gone": {
+			before:     []int{1, 2},
+			cursor:     1,
+			after:      nil,
+			wantCursor: 0,
+		},
+		"follows the PR within the filter": {
+			before:     []int{1, 2, 3},
+			cursor:     0,
+			filter:     "3",
+			after:      []int{13, 4, 3},
+			wantCursor: 1,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var tm tea.Model = newTestModel(needReviewInsights(tt.before...))
+			m := tm.(model)
+			m.selectedView = 1
+			m, _ = m.applyFilter(tt.filter)
+			m.cursor = tt.cursor
+			selected := m.getSelectedURL()
+
+			tm, _ = m.Update(fetchCompleteMsg{insights: needReviewInsights(tt.after...)})
+			m = tm.(model)
+
+			if m.cursor != tt.wantCursor {
+				t.Errorf("Expected cursor %d, got %d", tt.wantCursor, m.cursor)
+			}
+			if slices.ContainsFunc(m.insights.NeedReviewPRs, func(pr analyzer.ReviewNeededPR) bool { return pr.URL == selected }) &&
+				m.getSelectedURL() != selected {
+				t.Errorf("Expected the cursor to stay on %s, got %s", selected, m.getSelectedURL())
+			}
+		})
+	}
+}
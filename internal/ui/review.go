@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pippokairos/workflow-monitor/internal/analyzer"
+	"github.com/pippokairos/workflow-monitor/internal/gh"
+)
+
+const reviewWidth = 72
+
+var reviewEvents = []struct {
+	event string
+	label string
+}{
+	{gh.ReviewApprove, "Approve"},
+	{gh.ReviewComment, "Comment"},
+	{gh.ReviewRequestChanges, "Request changes"},
+}
+
+type reviewSubmittedMsg struct {
+	pr       gh.PullRequest
+	event    string
+	insights *analyzer.Insights
+	err      error
+}
+
+func newReviewTextarea() textarea.Model {
+	ta := textarea.New()
+	ta.Placeholder = "Leave a comment (optional when approving)"
+	ta.ShowLineNumbers = false
+	ta.SetWidth(reviewWidth)
+	ta.SetHeight(6)
+
+	return ta
+}
+
+func (m model) startReview() (tea.Model, tea.Cmd) {
+	_, pr, ok := m.getSelectedItem()
+	if m.selectedView != 1 || !ok {
+		m.notice = "Select a PR in the \"Need Review\" view to review it"
+		return m, nil
+	}
+
+	m.mode = modeReview
+	m.reviewPR = pr
+	m.reviewEvent = 0
+	m.reviewBody.Reset()
+	return m, m.reviewBody.Focus()
+}
+
+func (m model) updateReview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.mode = modeNormal
+		m.reviewBody.Blur()
+		return m, nil
+
+	case "ctrl+e":
+		m.reviewEvent = (m.reviewEvent + 1) % len(reviewEvents)
+		return m, nil
+
+	case "ctrl+s":
+		m.mode = modeNormal
+		m.reviewBody.Blur()
+		m.notice = ""
+		m.info = fmt.Sprintf("Submitting review for %s #%d...", m.reviewPR.Repo, m.reviewPR.Number)
+		return m, submitReviewCmd(m.fetcher, m.reviewPR, reviewEvents[m.reviewEvent].event, m.reviewBody.Value())
+	}
+
+	var cmd tea.Cmd
+	m.reviewBody, cmd = m.reviewBody.Update(msg)
+	return m, cmd
+}
+
+func (m model) handleReviewSubmitted(msg reviewSubmittedMsg) (tea.Model, tea.Cmd) {
+	m.info = ""
+	if msg.err != nil {
+		m.notice = msg.err.Error()
+		return m, nil
+	}
+
+	m.insights = msg.insights
+	m.fetchGeneration++
+	m.clampCursor()
+	m.info = fmt.Sprintf("Review submitted for %s #%d", msg.pr.Repo, msg.pr.Number)
+	return m, m.loadDetails()
+}
+
+func (m model) renderReviewComposer() string {
+	s := titleStyle.Render(fmt.Sprintf("Review %s #%d: %s", m.reviewPR.Repo, m.reviewPR.Number, m.reviewPR.Title)) + "\n\n"
+
+	for i, option := range reviewEvents {
+		if i == m.reviewEvent {
+			s += activeTabStyle.Render(option.label)
+		} else {
+			s += inactiveTabStyle.Render(option.label)
+		}
+		s += " "
+	}
+	s += "\n\n" + m.reviewBody.View() + "\n\n"
+	s += subtitleStyle.Render("Ctrl+E: change review type | Ctrl+S: submit | Esc: cancel")
+
+	return popupStyle.Render(s) + "\n\n"
+}
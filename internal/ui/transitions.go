@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pippokairos/workflow-monitor/internal/analyzer"
+	"github.com/pippokairos/workflow-monitor/internal/atlassian"
+)
+
+type transitionsLoadedMsg struct {
+	issueID     string
+	transitions []atlassian.Transition
+	err         error
+}
+
+type transitionDoneMsg struct {
+	issueID    string
+	transition atlassian.Transition
+	insights   *analyzer.Insights
+	err        error
+}
+
+func (m model) startTransition() (tea.Model, tea.Cmd) {
+	issueID := m.getSelectedIssueID()
+	if issueID == "" {
+		m.notice = "Select an item linked to a Jira ticket to transition it"
+		return m, nil
+	}
+
+	m.notice = ""
+	m.info = fmt.Sprintf("Loading transitions for %s...", issueID)
+	return m, fetchTransitionsCmd(m.fetcher, issueID)
+}
+
+func (m model) handleTransitionsLoaded(msg transitionsLoadedMsg) (tea.Model, tea.Cmd) {
+	m.info = ""
+	if msg.err != nil {
+		m.notice = msg.err.Error()
+		return m, nil
+	}
+
+	if len(msg.transitions) == 0 {
+		m.notice = fmt.Sprintf("No transitions available for %s", msg.issueID)
+		return m, nil
+	}
+
+	m.mode = modeTransitionPicker
+	m.transitionIssueID = msg.issueID
+	m.transitions = msg.transitions
+	m.transitionCursor = 0
+	return m, nil
+}
+
+func (m model) updateTransitionPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.mode = modeNormal
+		return m, nil
+
+	case "up", "k":
+		if m.transitionCursor > 0 {
+			m.transitionCursor--
+		}
+		return m, nil
+
+	case "down", "j":
+		if m.transitionCursor < len(m.transitions)-1 {
+			m.transitionCursor++
+		}
+		return m, nil
+
+	case "enter":
+		issueID := m.transitionIssueID
+		transition := m.transitions[m.transitionCursor]
+		return m.askConfirmation(
+			fmt.Sprintf("Move %s to %q?", issueID, transition.ToStatus),
+			fmt.Sprintf("Moving %s to %q...", issueID, transition.ToStatus),
+			transitionIssueCmd(m.fetcher, issueID, transition),
+		)
+	}
+
+	return m, nil
+}
+
+func (m model) handleTransitionDone(msg transitionDoneMsg) (tea.Model, tea.Cmd) {
+	m.info = ""
+	if msg.err != nil {
+		m.notice = msg.err.Error()
+		return m, nil
+	}
+
+	m.insights = msg.insights
+	m.fetchGeneration++
+	m.clampCursor()
+	m.forgetIssueDetails(msg.issueID)
+	m.info = fmt.Sprintf("Moved %s to %q", msg.issueID, msg.transition.ToStatus)
+	return m, m.loadDetails()
+}
+
+func (m model) renderTransitionPicker() string {
+	s := titleStyle.Render(fmt.Sprintf("Transition %s", m.transitionIssueID)) + "\n\n"
+	for i, transition := range m.transitions {
+		cursor := "  "
+		if i == m.transitionCursor {
+			cursor = cursorStyle.Render("▸ ")
+		}
+
+		s += fmt.Sprintf("%s%s %s\n", cursor, transition.Name, subtitleStyle.Render("→ "+transition.ToStatus))
+	}
+	s += "\n" + subtitleStyle.Render("Enter: select | Esc: cancel")
+
+	return popupStyle.Render(s) + "\n\n"
+}
@@ -5,11 +5,18 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/pippokairos/workflow-monitor/internal/analyzer"
+	"github.com/pippokairos/workflow-monitor/internal/atlassian"
+	"github.com/pippokairos/workflow-monitor/internal/config"
 	"github.com/pippokairos/workflow-monitor/internal/data"
+	"github.com/pippokairos/workflow-monitor/internal/gh"
 )
 
 var (
@@ -55,6 +62,17 @@ var (
 
 	statsStyle = lipgloss.NewStyle().
 			Italic(true)
+
+	noticeStyle = lipgloss.NewStyle().
+			Foreground(errorColor)
+
+	infoStyle = lipgloss.NewStyle().
+			Foreground(secondaryColor)
+
+	popupStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(primaryColor).
+			Padding(1, 2)
 )
 
 type state int
@@ -65,26 +83,91 @@ const (
 	stateError
 )
 
+// mode tracks which interaction currently owns the keyboard once data is loaded.
+type mode int
+
+const (
+	modeNormal mode = iota
+	modeTransitionPicker
+	modeConfirm
+	modeReview
+	modeFilter
+	modeErrorDetails
+	modeHelp
+)
+
+// confirmation is a pending action waiting for the user to answer y/n.
+type confirmation struct {
+	prompt  string
+	pending string // Shown while the action runs
+	action  tea.Cmd
+}
+
 type model struct {
 	state     state
-	err       error
+	err       error // Of the last fetch, shown over the previous data if any
 	fetcher   *data.Fetcher
 	insights  *analyzer.Insights
 	spinner   spinner.Model
 	startTime time.Time
 	loadTime  time.Duration
+	loadedAt  time.Time
+	rateLimit gh.RateLimit
+	notice    string // Errors of actions that don't prevent using the TUI
+	info      string // Progress and outcome of actions
+
+	refreshing bool               // Fetching again while showing the previous data
+	added      [3]map[string]bool // PR URLs of the items that appeared with the last refresh, by view
+	removed    [3][]removedItem
+
+	refreshInterval time.Duration // 0 disables the automatic refresh
+	untilRefresh    time.Duration
+	focused         bool
+
+	errDismissed bool
+	retryAttempt int
+	retryAt      time.Time // Of the scheduled automatic retry
 
 	selectedView int // 0, 1, or 2 for the three views
 	cursor       int // Selected item
+	offset       int // First item shown in the viewport
+	filter       string
+	filterInput  textinput.Model
+
+	width  int // Terminal size, 0 until known
+	height int
+
+	keys keyMap
+	help help.Model
+
+	mode              mode
+	confirm           confirmation
+	transitionIssueID string
+	transitions       []atlassian.Transition
+	transitionCursor  int
+
+	reviewPR    gh.PullRequest
+	reviewEvent int // Index in reviewEvents
+	reviewBody  textarea.Model
+
+	showDetails bool
+	details     map[string]*detailsEntry
+	generation  int // Incremented on every refresh, to discard outdated details
+
+	// Incremented by actions returning fresh insights, to discard the fetches
+	// started before them
+	fetchGeneration int
 }
 
 type fetchCompleteMsg struct {
-	insights *analyzer.Insights
-	err      error
-	duration time.Duration
+	generation int
+	insights   *analyzer.Insights
+	err        error
+	duration   time.Duration
+	rateLimit  gh.RateLimit
 }
 
-func InitialModel(fetcher *data.Fetcher) model {
+func InitialModel(fetcher *data.Fetcher, cfg *config.Config) model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(primaryColor)
@@ -96,28 +179,98 @@ func InitialModel(fetcher *data.Fetcher) model {
 		cursor:       0,
 		spinner:      s,
 		startTime:    time.Now(),
+		reviewBody:   newReviewTextarea(),
+		filterInput:  newFilterInput(),
+		details:      make(map[string]*detailsEntry),
+
+		keys: newKeyMap(cfg.KeyBindings()),
+		help: help.New(),
+
+		refreshInterval: cfg.AutoRefreshInterval,
+		untilRefresh:    cfg.AutoRefreshInterval,
+		focused:         true,
 	}
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		m.spinner.Tick,
-		fetchDataCmd(m.fetcher, m.startTime),
-	)
+		fetchDataCmd(m.fetcher, m.startTime, m.fetchGeneration),
+	}
+	if m.refreshInterval > 0 {
+		cmds = append(cmds, autoRefreshTickCmd())
+	}
+
+	return tea.Batch(cmds...)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
 	case fetchCompleteMsg:
+		m.refreshing = false
+		m.untilRefresh = m.refreshInterval
+		if msg.generation != m.fetchGeneration {
+			return m, nil
+		}
 		if msg.err != nil {
-			m.state = stateError
-			m.err = msg.err
-			return m, tea.Quit
+			return m.handleFetchError(msg.err)
 		}
+
+		var selectedURL string
+		if m.insights != nil {
+			selectedURL = m.getSelectedURL()
+			m.trackChanges(msg.insights)
+		}
+
 		m.state = stateReady
+		m.err = nil
+		m.retryAttempt = 0
 		m.insights = msg.insights
 		m.loadTime = msg.duration
+		m.loadedAt = time.Now()
+		m.rateLimit = msg.rateLimit
+		m.retryAt = time.Time{}
+		m.details = make(map[string]*detailsEntry)
+		m.generation++
+		m.restoreCursor(selectedURL)
+		return m, m.loadDetails()
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.reviewBody.SetWidth(min(reviewWidth, max(20, msg.Width-8)))
+		m.help.Width = msg.Width
+		m.scrollToCursor()
+		return m, nil
+
+	case transitionsLoadedMsg:
+		return m.handleTransitionsLoaded(msg)
+
+	case transitionDoneMsg:
+		return m.handleTransitionDone(msg)
+
+	case prMergedMsg:
+		return m.handlePRMerged(msg)
+
+	case reviewSubmittedMsg:
+		return m.handleReviewSubmitted(msg)
+
+	case detailsLoadedMsg:
+		return m.handleDetailsLoaded(msg)
+
+	case retryMsg:
+		return m.handleRetry(msg)
+
+	case autoRefreshTickMsg:
+		return m.handleAutoRefreshTick()
+
+	case tea.FocusMsg:
+		m.focused = true
+		return m, nil
+
+	case tea.BlurMsg:
+		m.focused = false
 		return m, nil
 
 	case spinner.TickMsg:
@@ -131,83 +284,166 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		if m.state == stateError {
-			return m, tea.Quit
+			return m.updateFetchError(msg)
+		}
+
+		switch m.mode {
+		case modeTransitionPicker:
+			return m.updateTransitionPicker(msg)
+		case modeConfirm:
+			return m.updateConfirm(msg)
+		case modeReview:
+			return m.updateReview(msg)
+		case modeFilter:
+			return m.updateFilter(msg)
+		case modeErrorDetails:
+			return m.updateErrorDetails(msg)
+		case modeHelp:
+			return m.updateHelp(msg)
 		}
 
-		switch msg.String() {
-		case "q", "ctrl+c":
+		switch {
+		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
 
-		case "tab":
-			// Switch view
-			m.selectedView = (m.selectedView + 1) % 3
-			m.cursor = 0
+		case key.Matches(msg, m.keys.Help):
+			m.mode = modeHelp
 			return m, nil
 
-		case "up", "k":
-			if m.cursor > 0 {
-				m.cursor--
-			}
-			return m, nil
+		case key.Matches(msg, m.keys.Filter):
+			return m.startFilter()
 
-		case "down", "j":
-			max := m.getMaxCursor()
-			if m.cursor < max {
-				m.cursor++
+		case key.Matches(msg, m.keys.ClearFilter):
+			if m.filter != "" {
+				m, cmd := m.applyFilter("")
+				return m, cmd
 			}
 			return m, nil
 
-		case "enter":
+		case key.Matches(msg, m.keys.SwitchView):
+			m.selectedView = (m.selectedView + 1) % 3
+			m.cursor = 0
+			m.offset = 0
+			return m, m.loadDetails()
+
+		case key.Matches(msg, m.keys.Up):
+			m.moveCursor(-1)
+			return m, m.loadDetails()
+
+		case key.Matches(msg, m.keys.Down):
+			m.moveCursor(1)
+			return m, m.loadDetails()
+
+		case key.Matches(msg, m.keys.PageUp):
+			m.moveCursor(-max(1, m.pageSize()))
+			return m, m.loadDetails()
+
+		case key.Matches(msg, m.keys.PageDown):
+			m.moveCursor(max(1, m.pageSize()))
+			return m, m.loadDetails()
+
+		case key.Matches(msg, m.keys.Top):
+			m.moveCursor(-m.cursor)
+			return m, m.loadDetails()
+
+		case key.Matches(msg, m.keys.Bottom):
+			m.moveCursor(m.getMaxCursor() - m.cursor)
+			return m, m.loadDetails()
+
+		case key.Matches(msg, m.keys.Open):
 			url := m.getSelectedURL()
 			if url != "" {
 				return m, openURLCmd(url)
 			}
 			return m, nil
 
-		case "r":
-			// Refresh
-			m.state = stateLoading
-			m.cursor = 0
-			m.startTime = time.Now()
-			return m, tea.Batch(
-				m.spinner.Tick,
-				fetchDataCmd(m.fetcher, m.startTime),
-			)
+		case key.Matches(msg, m.keys.Details):
+			return m.toggleDetails()
+
+		case key.Matches(msg, m.keys.Transition):
+			return m.startTransition()
+
+		case key.Matches(msg, m.keys.Merge):
+			return m.startMerge()
+
+		case key.Matches(msg, m.keys.Review):
+			return m.startReview()
+
+		case key.Matches(msg, m.keys.ErrorDetails):
+			if m.err != nil {
+				m.mode = modeErrorDetails
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.DismissError):
+			m.errDismissed = true
+			return m, nil
+
+		case key.Matches(msg, m.keys.Refresh):
+			return m.refresh()
 		}
+
+	default:
+		// Keeps the review composer and filter cursors blinking
+		var cmd tea.Cmd
+		switch m.mode {
+		case modeReview:
+			m.reviewBody, cmd = m.reviewBody.Update(msg)
+		case modeFilter:
+			m.filterInput, cmd = m.filterInput.Update(msg)
+		}
+		return m, cmd
 	}
 
 	return m, nil
 }
 
-func (m model) getMaxCursor() int {
-	switch m.selectedView {
-	case 0:
-		return len(m.insights.DoneNotMergedPRs) - 1
-	case 1:
-		return len(m.insights.NeedReviewPRs) - 1
-	case 2:
-		return len(m.insights.ReviewedNotInQAPRs) - 1
+func (m model) askConfirmation(prompt, pending string, action tea.Cmd) (tea.Model, tea.Cmd) {
+	m.mode = modeConfirm
+	m.confirm = confirmation{
+		prompt:  prompt,
+		pending: pending,
+		action:  action,
 	}
-	return 0
+	return m, nil
 }
 
-func (m model) getSelectedURL() string {
-	switch m.selectedView {
-	case 0:
-		if m.cursor < len(m.insights.DoneNotMergedPRs) {
-			return m.insights.DoneNotMergedPRs[m.cursor].PullRequest.URL
-		}
-	case 1:
-		if m.cursor < len(m.insights.NeedReviewPRs) {
-			return m.insights.NeedReviewPRs[m.cursor].URL
-		}
-	case 2:
-		if m.cursor < len(m.insights.ReviewedNotInQAPRs) {
-			return m.insights.ReviewedNotInQAPRs[m.cursor].PullRequest.URL
-		}
+func (m model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		m.mode = modeNormal
+		m.notice = ""
+		m.info = m.confirm.pending
+		return m, m.confirm.action
+
+	case "n", "N", "esc", "q":
+		m.mode = modeNormal
+		return m, nil
 	}
 
-	return ""
+	return m, nil
+}
+
+// clampCursor keeps the cursor in range after items are removed from the current view.
+func (m *model) clampCursor() {
+	if last := m.getMaxCursor(); m.cursor > last {
+		m.cursor = max(0, last)
+	}
+	m.scrollToCursor()
+}
+
+func (m model) getMaxCursor() int {
+	return len(m.filteredItems(m.selectedView)) - 1
+}
+
+func (m model) getSelectedURL() string {
+	_, pr, _ := m.getSelectedItem()
+	return pr.URL
+}
+
+func (m model) getSelectedIssueID() string {
+	issueID, _, _ := m.getSelectedItem()
+	return issueID
 }
 
 func (m model) View() string {
@@ -219,122 +455,183 @@ func (m model) View() string {
 	}
 
 	if m.state == stateError {
-		errorMsg := lipgloss.NewStyle().
-			Foreground(errorColor).
-			Bold(true).
-			Render(fmt.Sprintf("Error: %v", m.err))
-		return fmt.Sprintf("\n%s\n\n%s\n", errorMsg, subtitleStyle.Render("Press any key to exit."))
+		return m.renderFetchError()
+	}
+
+	header := m.renderHeader()
+	footer := m.renderFooter()
+
+	// Content
+	var content string
+	switch m.mode {
+	case modeTransitionPicker:
+		content = m.renderTransitionPicker()
+	case modeReview:
+		content = m.renderReviewComposer()
+	case modeErrorDetails:
+		content = m.renderErrorDetails()
+	case modeHelp:
+		content = m.renderHelp()
+	case modeConfirm:
+		content = popupStyle.Render(m.confirm.prompt+"\n\n"+subtitleStyle.Render("y: confirm | n: cancel")) + "\n\n"
+	default:
+		content = m.renderContent()
+	}
+
+	return header + content + footer
+}
+
+func (m model) renderHeader() string {
+	tabs := []string{"Ticket done, PRs not merged", "Need Review", "Ready for QA"}
+	for i := range tabs {
+		if m.filter != "" {
+			tabs[i] += fmt.Sprintf(" (%d/%d)", len(m.filteredItems(i)), viewLen(m.insights, i))
+		} else {
+			tabs[i] += fmt.Sprintf(" (%d)", viewLen(m.insights, i))
+		}
+	}
+
+	// Add load time and GitHub quota
+	stats := fmt.Sprintf("  Data loaded in %s", m.loadTime.Round(10*time.Millisecond))
+	if m.rateLimit.Known() {
+		stats += fmt.Sprintf(" | GitHub API: %d/%d left, resets at %s",
+			m.rateLimit.Remaining, m.rateLimit.Limit, m.rateLimit.Reset.Format("15:04"))
+	}
+	if m.refreshing {
+		elapsed := time.Since(m.startTime).Round(100 * time.Millisecond)
+		stats = fmt.Sprintf("  %s Refreshing... (%s)", m.spinner.View(), elapsed)
 	}
+	header := "\n" + statsStyle.Render(truncate(stats, m.width)) + "\n\n"
+	header += m.renderBanner()
 
-	// Header with tabs
-	tabs := []string{
-		fmt.Sprintf("Ticket done, PRs not merged (%d)", len(m.insights.DoneNotMergedPRs)),
-		fmt.Sprintf("Need Review (%d)", len(m.insights.NeedReviewPRs)),
-		fmt.Sprintf("Ready for QA (%d)", len(m.insights.ReviewedNotInQAPRs)),
+	if m.notice != "" {
+		header += noticeStyle.Render(truncate("  "+m.notice, m.width)) + "\n\n"
 	}
 
-	// Add load time
-	header := "\n" + statsStyle.Render(fmt.Sprintf("  Data loaded in %s", m.loadTime.Round(10*time.Millisecond))) + "\n\n"
+	if m.info != "" {
+		header += infoStyle.Render(truncate("  "+m.info, m.width)) + "\n\n"
+	}
 
+	tabsLine := ""
 	for i, tab := range tabs {
 		if i == m.selectedView {
-			header += activeTabStyle.Render(tab)
+			tabsLine += activeTabStyle.Render(tab)
 		} else {
-			header += inactiveTabStyle.Render(tab)
+			tabsLine += inactiveTabStyle.Render(tab)
 		}
-		header += " "
+		tabsLine += " "
+	}
+	header += truncate(tabsLine, m.width) + "\n\n"
+
+	switch {
+	case m.mode == modeFilter:
+		header += "  " + m.filterInput.View() + "\n\n"
+	case m.filter != "":
+		filter := fmt.Sprintf("  Filter: %s (%s: edit, %s: clear)", m.filter, m.keys.Filter.Help().Key, m.keys.ClearFilter.Help().Key)
+		header += subtitleStyle.Render(truncate(filter, m.width)) + "\n\n"
 	}
-	header += "\n\n"
 
-	// Content
-	var content string
+	return header
+}
+
+func (m model) renderFooter() string {
+	style := footerStyle
+	if m.width > 0 {
+		style = style.Width(m.width)
+	}
+
+	return style.Render(m.help.ShortHelpView(m.keys.ShortHelp()) + subtitleStyle.Render(m.renderAutoRefresh()))
+}
+
+// renderContent renders the list of the current view, next to the detail pane when it's shown.
+func (m model) renderContent() string {
+	listWidth := m.width
+	var details string
+	if m.showDetails {
+		details = m.renderDetails()
+		if m.width > 0 {
+			listWidth = max(20, m.width-lipgloss.Width(details))
+		}
+	}
+
+	var list string
 	switch m.selectedView {
 	case 0:
-		content = m.renderDoneNotMergedPRs()
+		list = m.renderList(m.doneNotMergedItems(), listWidth)
 	case 1:
-		content = m.renderNeedReviewPRs()
+		list = m.renderList(m.needReviewItems(), listWidth)
 	case 2:
-		content = m.renderReviewedNotInQAPRs()
+		list = m.renderList(m.reviewedNotInQAItems(), listWidth)
 	}
 
-	// Footer
-	footer := footerStyle.Render(
-		"Tab: switch view | ↑/↓ or j/k: navigate | Enter: open in browser | r: refresh | q: quit",
-	)
+	list += m.renderRemoved()
 
-	return header + content + footer
-}
-
-func (m model) renderDoneNotMergedPRs() string {
-	if len(m.insights.DoneNotMergedPRs) == 0 {
-		return renderNoItemsFoundMessage()
+	if details == "" {
+		return list
 	}
 
-	s := ""
-	for i, item := range m.insights.DoneNotMergedPRs {
-		cursor := "  "
-		if i == m.cursor {
-			cursor = cursorStyle.Render("▸ ")
-		}
-
-		ticketBadge := numberStyle.Render(fmt.Sprintf("%s", item.IssueID))
-		title := titleStyle.Render(item.PullRequest.Title)
-		prInfo := subtitleStyle.Render(fmt.Sprintf("PR #%d by %s (open)", item.PullRequest.Number, item.PullRequest.Author))
-
-		s += fmt.Sprintf("%s%s %s\n    %s\n\n", cursor, ticketBadge, title, prInfo)
+	if m.height > 0 {
+		details = lipgloss.NewStyle().MaxHeight(m.height - m.headerHeight() - lipgloss.Height(m.renderFooter())).Render(details)
 	}
 
-	return s
+	return lipgloss.JoinHorizontal(lipgloss.Top, lipgloss.NewStyle().Width(listWidth).Render(list), details) + "\n\n"
 }
 
-func (m model) renderNeedReviewPRs() string {
-	if len(m.insights.NeedReviewPRs) == 0 {
-		return renderNoItemsFoundMessage()
+func (m model) doneNotMergedItems() []string {
+	var items []string
+	for _, match := range m.filteredItems(0) {
+		item := m.insights.DoneNotMergedPRs[match.index]
+		ticketBadge := highlight(item.IssueID, match.key, numberStyle)
+		title := highlight(item.PullRequest.Title, match.title, titleStyle) + m.newBadge(0, item.PullRequest)
+		prInfo := subtitleStyle.Render(fmt.Sprintf("PR #%d by ", item.PullRequest.Number)) +
+			highlight(item.PullRequest.Author, match.author, subtitleStyle) +
+			subtitleStyle.Render(" in ") +
+			highlight(item.PullRequest.Repo, match.repo, subtitleStyle) +
+			subtitleStyle.Render(" (open)")
+
+		items = append(items, fmt.Sprintf("%s %s\n    %s", ticketBadge, title, prInfo))
 	}
 
-	s := ""
-	for i, pr := range m.insights.NeedReviewPRs {
-		cursor := "  "
-		if i == m.cursor {
-			cursor = cursorStyle.Render("▸ ")
-		}
+	return items
+}
 
+func (m model) needReviewItems() []string {
+	var items []string
+	for _, match := range m.filteredItems(1) {
+		pr := m.insights.NeedReviewPRs[match.index]
 		prBadge := numberStyle.Render(fmt.Sprintf("#%d", pr.Number))
-		title := titleStyle.Render(pr.Title)
-		info := subtitleStyle.Render(fmt.Sprintf("PR by %s in %s", pr.Author, pr.Repo))
+		title := highlight(pr.Title, match.title, titleStyle) + m.newBadge(1, gh.PullRequest(pr))
+		info := subtitleStyle.Render("PR by ") +
+			highlight(pr.Author, match.author, subtitleStyle) +
+			subtitleStyle.Render(" in ") +
+			highlight(pr.Repo, match.repo, subtitleStyle)
 
-		s += fmt.Sprintf("%s%s %s\n    %s\n\n", cursor, prBadge, title, info)
+		items = append(items, fmt.Sprintf("%s %s\n    %s", prBadge, title, info))
 	}
 
-	return s
+	return items
 }
 
-func (m model) renderReviewedNotInQAPRs() string {
-	if len(m.insights.ReviewedNotInQAPRs) == 0 {
-		return renderNoItemsFoundMessage()
-	}
-
-	s := ""
-	for i, item := range m.insights.ReviewedNotInQAPRs {
-		cursor := "  "
-		if i == m.cursor {
-			cursor = cursorStyle.Render("▸ ")
-		}
-
-		ticketBadge := successBadgeStyle.Render(fmt.Sprintf("%s", item.IssueID))
-		title := titleStyle.Render(item.PullRequest.Title)
+func (m model) reviewedNotInQAItems() []string {
+	var items []string
+	for _, match := range m.filteredItems(2) {
+		item := m.insights.ReviewedNotInQAPRs[match.index]
+		ticketBadge := highlight(item.IssueID, match.key, successBadgeStyle)
+		title := highlight(item.PullRequest.Title, match.title, titleStyle) + m.newBadge(2, item.PullRequest)
 
-		approvers := fmt.Sprintf("PR #%d", item.PullRequest.Number)
+		// The author is always me here, so it's left out
+		info := subtitleStyle.Render(fmt.Sprintf("PR #%d in ", item.PullRequest.Number)) +
+			highlight(item.PullRequest.Repo, match.repo, subtitleStyle)
 		if len(item.PullRequest.Approvers) > 0 {
-			approvers = subtitleStyle.Render(fmt.Sprintf("%s approved by: %s", approvers, strings.Join(item.PullRequest.Approvers, ", ")))
+			info += subtitleStyle.Render(fmt.Sprintf(" approved by: %s", strings.Join(item.PullRequest.Approvers, ", ")))
 		} else {
-			approvers = subtitleStyle.Render(fmt.Sprintf("%s - no approvals yet", approvers))
+			info += subtitleStyle.Render(" - no approvals yet")
 		}
 
-		s += fmt.Sprintf("%s%s %s\n    %s\n\n", cursor, ticketBadge, title, approvers)
+		items = append(items, fmt.Sprintf("%s %s\n    %s", ticketBadge, title, info))
 	}
 
-	return s
+	return items
 }
 
 func renderNoItemsFoundMessage() string {
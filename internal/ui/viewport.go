@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Every list item takes two lines plus a blank one.
+const itemHeight = 3
+
+// pageSize is how many items of the current view fit in the terminal, or 0 when
+// its size isn't known yet.
+func (m model) pageSize() int {
+	if m.height == 0 {
+		return 0
+	}
+
+	// The position indicator below the list takes a line
+	height := m.height - m.headerHeight() - lipgloss.Height(m.renderFooter()) - 1
+	if removed := m.renderRemoved(); removed != "" {
+		height -= lipgloss.Height(removed)
+	}
+	return max(1, height/itemHeight)
+}
+
+// headerHeight is the height of renderHeader, counted from its layout rather than
+// rendered as the tab counters filter every view.
+func (m model) headerHeight() int {
+	// A blank line, then the stats and the tabs, each followed by a blank line
+	height := 6
+	if banner := m.renderBanner(); banner != "" {
+		height += lipgloss.Height(banner) - 1
+	}
+	if m.notice != "" {
+		height += lipgloss.Height(m.notice) + 1
+	}
+	if m.info != "" {
+		height += lipgloss.Height(m.info) + 1
+	}
+	if m.mode == modeFilter || m.filter != "" {
+		height += 2
+	}
+	return height
+}
+
+// scrollToCursor moves the viewport just enough for the cursor to be visible.
+func (m *model) scrollToCursor() {
+	m.offset = visibleOffset(m.offset, m.cursor, m.pageSize())
+}
+
+func visibleOffset(offset, cursor, pageSize int) int {
+	if pageSize == 0 {
+		return 0
+	}
+
+	if cursor < offset {
+		return cursor
+	}
+
+	if cursor >= offset+pageSize {
+		return cursor - pageSize + 1
+	}
+
+	return offset
+}
+
+func (m *model) moveCursor(delta int) {
+	m.cursor = min(max(0, m.cursor+delta), max(0, m.getMaxCursor()))
+	m.scrollToCursor()
+}
+
+// renderList renders the visible window of items, each made of a first line
+// prefixed by the cursor and the following indented ones, truncated to width.
+func (m model) renderList(items []string, width int) string {
+	if len(items) == 0 {
+		return renderNoItemsFoundMessage()
+	}
+
+	pageSize := m.pageSize()
+	if pageSize == 0 {
+		pageSize = len(items)
+	}
+	// The header may have grown since the last scroll, e.g. to show a notice
+	offset := visibleOffset(m.offset, m.cursor, pageSize)
+	end := min(len(items), offset+pageSize)
+
+	s := ""
+	for i := offset; i < end; i++ {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = cursorStyle.Render("▸ ")
+		}
+
+		for _, line := range strings.Split(cursor+items[i], "\n") {
+			s += truncate(line, width) + "\n"
+		}
+		s += "\n"
+	}
+
+	if offset > 0 || end < len(items) {
+		s += subtitleStyle.Render(fmt.Sprintf("  %d-%d of %d", offset+1, end, len(items))) + "\n"
+	}
+
+	return s
+}
+
+// truncate shortens a possibly styled line to width, doing nothing when the width
+// isn't known.
+func truncate(line string, width int) string {
+	if width <= 0 {
+		return line
+	}
+
+	return ansi.Truncate(line, width, "…")
+}
@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/pippokairos/workflow-monitor/internal/analyzer"
+	"github.com/pippokairos/workflow-monitor/internal/config"
+)
+
+// newTestModel returns a model showing insights, as after a successful fetch.
+func newTestModel(insights *analyzer.Insights) model {
+	m := InitialModel(nil, &config.Config{})
+	m.state = stateReady
+	m.insights = insights
+	return m
+}
+
+func TestVisibleOffset(t *testing.T) {
+	tests := map[string]struct {
+		offset   int
+		cursor   int
+		pageSize int
+		want     int
+	}{
+		"unknown size":                 {offset: 3, cursor: 5, pageSize: 0, want: 0},
+		"cursor in page":               {offset: 2, cursor: 4, pageSize: 3, want: 2},
+		"cursor above page":            {offset: 4, cursor: 1, pageSize: 3, want: 1},
+		"cursor below page":            {offset: 0, cursor: 5, pageSize: 3, want: 3},
+		"cursor on last line of page":  {offset: 2, cursor: 4, pageSize: 3, want: 2},
+		"cursor just past page":        {offset: 2, cursor: 5, pageSize: 3, want: 3},
+		"single item page":             {offset: 0, cursor: 7, pageSize: 1, want: 7},
+		"cursor on first line of page": {offset: 6, cursor: 6, pageSize: 3, want: 6},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := visibleOffset(tt.offset, tt.cursor, tt.pageSize); got != tt.want {
+				t.Errorf("Expected offset %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHeaderHeight(t *testing.T) {
+	tests := map[string]struct {
+		width  int
+		update func(m *model)
+	}{
+		"plain": {
+			width:  80,
+			update: func(m *model) {},
+		},
+		"notice and info": {
+			width: 80,
+			update: func(m *model) {
+				m.notice = "Merge failed"
+				m.info = "Merging owner/repo #1..."
+			},
+		},
+		"multiline notice": {
+			width: 80,
+			update: func(m *model) {
+				m.notice = "first error\nsecond error"
+			},
+		},
+		"banner": {
+			width: 80,
+			update: func(m *model) {
+				m.err = errors.New("connection refused")
+			},
+		},
+		"dismissed banner": {
+			width: 80,
+			update: func(m *model) {
+				m.err = errors.New("connection refused")
+				m.errDismissed = true
+			},
+		},
+		"filter": {
+			width: 80,
+			update: func(m *model) {
+				m.filter = "login"
+			},
+		},
+		"editing filter": {
+			width: 80,
+			update: func(m *model) {
+				m.mode = modeFilter
+			},
+		},
+		"everything at narrow width": {
+			width: 12,
+			update: func(m *model) {
+				m.notice = "Merge failed"
+				m.info = "Merging owner/repo #1..."
+				m.err = errors.New("connection refused")
+				m.filter = "login"
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := newTestModel(&analyzer.Insights{})
+			m.width = tt.width
+			tt.update(&m)
+
+			if got, want := m.headerHeight(), lipgloss.Height(m.renderHeader()); got != want {
+				t.Errorf("Expected header height %d, got %d", want, got)
+			}
+		})
+	}
+}
+
+func TestRenderListTruncation(t *testing.T) {
+	title := "Add a login page with remember me and password reset"
+	insights := &analyzer.Insights{
+		NeedReviewPRs: []analyzer.ReviewNeededPR{
+			{Number: 1, Title: title, Author: "alice", Repo: "owner/repo"},
+		},
+	}
+
+	tests := map[string]struct {
+		width int
+	}{
+		"unknown width": {width: 0},
+		"wide":          {width: 200},
+		"narrow":        {width: 20},
+		"very narrow":   {width: 3},
+		"single column": {width: 1},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := newTestModel(insights)
+			m.selectedView = 1
+
+			list := m.renderList(m.needReviewItems(), tt.width)
+
+			if tt.width == 0 || tt.width > len(title)+10 {
+				if !strings.Contains(ansi.Strip(list), title) {
+					t.Errorf("Expected the full title, got %q", ansi.Strip(list))
+				}
+				return
+			}
+
+			for _, line := range strings.Split(list, "\n") {
+				if w := ansi.StringWidth(line); w > tt.width {
+					t.Errorf("Expected lines at most %d wide, got %d: %q", tt.width, w, ansi.Strip(line))
+				}
+			}
+			if !strings.Contains(list, "…") {
+				t.Errorf("Expected truncated lines to end with an ellipsis, got %q", ansi.Strip(list))
+			}
+		})
+	}
+}
+
+func TestPageSize(t *testing.T) {
+	items := make([]analyzer.ReviewNeededPR, 20)
+	for i := range items {
+		items[i] = analyzer.ReviewNeededPR{Number: i + 1, Title: "PR", Repo: "owner/repo"}
+	}
+
+	m := newTestModel(&analyzer.Insights{NeedReviewPRs: items})
+	m.selectedView = 1
+	m.width = 80
+	m.height = 40
+
+	pageSize := m.pageSize()
+	if pageSize < 1 || pageSize >= len(items) {
+		t.Fatalf("Expected a page smaller than the %d items, got %d", len(items), pageSize)
+	}
+
+	m.moveCursor(len(items))
+	if got := lipgloss.Height(m.View()); got > m.height {
+		t.Errorf("Expected the view to fit in %d lines, got %d", m.height, got)
+	}
+
+	m.notice = "first error\nsecond error\nthird error"
+	m.info = "Merging owner/repo #1..."
+	if m.pageSize() >= pageSize {
+		t.Errorf("Expected messages to shrink the page below %d items, got %d", pageSize, m.pageSize())
+	}
+	if got := lipgloss.Height(m.View()); got > m.height {
+		t.Errorf("Expected the view with messages to fit in %d lines, got %d", m.height, got)
+	}
+}
@@ -0,0 +1,106 @@
+package wizard
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pippokairos/workflow-monitor/internal/atlassian"
+	"github.com/pippokairos/workflow-monitor/internal/config"
+	"github.com/pippokairos/workflow-monitor/internal/gh"
+)
+
+const requestTimeout = 30 * time.Second
+
+type projectsLoadedMsg struct {
+	projects []atlassian.Project
+	err      error
+}
+
+type statusesLoadedMsg struct {
+	statuses []string
+	err      error
+}
+
+type githubLoadedMsg struct {
+	login string
+	repos []string
+	err   error
+}
+
+type configWrittenMsg struct {
+	err error
+}
+
+// The clients are created from a copy of the answers so far, which is all they read.
+
+func fetchProjectsCmd(cfg config.Config) tea.Cmd {
+	return func() tea.Msg {
+		client, err := atlassian.NewClient(&cfg)
+		if err != nil {
+			return projectsLoadedMsg{err: err}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+
+		projects, err := client.ListProjects(ctx)
+		return projectsLoadedMsg{projects: projects, err: err}
+	}
+}
+
+// fetchStatusesCmd returns the statuses of all the given projects, in the order found.
+func fetchStatusesCmd(cfg config.Config) tea.Cmd {
+	return func() tea.Msg {
+		client, err := atlassian.NewClient(&cfg)
+		if err != nil {
+			return statusesLoadedMsg{err: err}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+
+		var statuses []string
+		for _, key := range cfg.AtlassianProjectKeys {
+			projectStatuses, err := client.ProjectStatuses(ctx, key)
+			if err != nil {
+				return statusesLoadedMsg{err: err}
+			}
+
+			for _, status := range projectStatuses {
+				if !slices.Contains(statuses, status) {
+					statuses = append(statuses, status)
+				}
+			}
+		}
+
+		return statusesLoadedMsg{statuses: statuses}
+	}
+}
+
+func fetchGitHubCmd(cfg config.Config) tea.Cmd {
+	return func() tea.Msg {
+		client, err := gh.NewClient(&cfg)
+		if err != nil {
+			return githubLoadedMsg{err: err}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+
+		login, _, err := client.TokenOwner(ctx)
+		if err != nil {
+			return githubLoadedMsg{err: err}
+		}
+
+		repos, err := client.ListRepos(ctx)
+		return githubLoadedMsg{login: login, repos: repos, err: err}
+	}
+}
+
+func writeConfigCmd(path string, cfg config.Config) tea.Cmd {
+	return func() tea.Msg {
+		return configWrittenMsg{err: writeConfig(path, &cfg)}
+	}
+}
@@ -0,0 +1,94 @@
+package wizard
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pippokairos/workflow-monitor/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+const configHeader = "# Generated by workflow-monitor init, see config.yml.example for all the options\n"
+
+// generatedConfig holds the settings asked by the wizard, in the order of config.yml.example.
+type generatedConfig struct {
+	AtlassianURL          string   `yaml:"atlassian_url"`
+	AtlassianEmail        string   `yaml:"atlassian_email"`
+	AtlassianToken        string   `yaml:"atlassian_token"`
+	AtlassianStatusReview string   `yaml:"atlassian_status_review"`
+	AtlassianStatusDone   string   `yaml:"atlassian_status_done"`
+	AtlassianProjectKeys  []string `yaml:"atlassian_project_keys"`
+
+	GitHubUsername          string   `yaml:"github_username"`
+	GitHubToken             string   `yaml:"github_token"`
+	GitHubRequiredApprovers int      `yaml:"github_required_approvers"`
+	GitHubRepos             []string `yaml:"github_repos"`
+
+	IssuePattern string `yaml:"issue_pattern"`
+}
+
+// ProposeIssuePattern returns an issue_pattern matching the keys of the given projects.
+func ProposeIssuePattern(projectKeys []string) string {
+	switch len(projectKeys) {
+	case 0:
+		return `([A-Z][A-Z0-9]+-\d+)`
+	case 1:
+		return fmt.Sprintf(`(%s-\d+)`, regexp.QuoteMeta(projectKeys[0]))
+	}
+
+	quoted := make([]string, len(projectKeys))
+	for i, key := range projectKeys {
+		quoted[i] = regexp.QuoteMeta(key)
+	}
+
+	return fmt.Sprintf(`((?:%s)-\d+)`, strings.Join(quoted, "|"))
+}
+
+// render validates the config and returns the content of its file, escaping $ as
+// $$ so that values (e.g. tokens) aren't taken for environment variables on load.
+func render(cfg *config.Config) ([]byte, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := regexp.Compile(cfg.IssuePattern); err != nil {
+		return nil, fmt.Errorf("issue_pattern is invalid: %w", err)
+	}
+
+	data, err := yaml.Marshal(generatedConfig{
+		AtlassianURL:          cfg.AtlassianURL,
+		AtlassianEmail:        cfg.AtlassianEmail,
+		AtlassianToken:        cfg.AtlassianToken,
+		AtlassianStatusReview: cfg.AtlassianStatusReview,
+		AtlassianStatusDone:   cfg.AtlassianStatusDone,
+		AtlassianProjectKeys:  cfg.AtlassianProjectKeys,
+
+		GitHubUsername:          cfg.GitHubUsername,
+		GitHubToken:             cfg.GitHubToken,
+		GitHubRequiredApprovers: cfg.GitHubRequiredApprovers,
+		GitHubRepos:             cfg.GitHubRepos,
+
+		IssuePattern: cfg.IssuePattern,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(configHeader), strings.ReplaceAll(string(data), "$", "$$")...), nil
+}
+
+// writeConfig writes the config file, readable only by the user as it holds tokens.
+func writeConfig(path string, cfg *config.Config) error {
+	data, err := render(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
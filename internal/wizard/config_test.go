@@ -0,0 +1,141 @@
+package wizard
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pippokairos/workflow-monitor/internal/config"
+)
+
+func TestProposeIssuePattern(t *testing.T) {
+	tests := map[string]struct {
+		keys      []string
+		matches   []string
+		noMatches []string
+	}{
+		"no projects": {
+			matches:   []string{"feature/PROJ-123-login", "OPS2-7"},
+			noMatches: []string{"fix-typo"},
+		},
+		"one project": {
+			keys:      []string{"PROJ"},
+			matches:   []string{"feature/PROJ-123-login"},
+			noMatches: []string{"feature/OPS-123-login"},
+		},
+		"several projects": {
+			keys:      []string{"PROJ", "OPS"},
+			matches:   []string{"feature/PROJ-123-login", "OPS-7"},
+			noMatches: []string{"feature/OTHER-1"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			pattern := regexp.MustCompile(ProposeIssuePattern(tt.keys))
+
+			for _, branch := range tt.matches {
+				want := regexp.MustCompile(`[A-Z][A-Z0-9]+-\d+`).FindString(branch)
+				if got := pattern.FindString(branch); got != want {
+					t.Errorf("Expected %s to find %s, got %q", pattern, want, got)
+				}
+			}
+			for _, branch := range tt.noMatches {
+				if pattern.MatchString(branch) {
+					t.Errorf("Expected %s not to match %s", pattern, branch)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteConfig(t *testing.T) {
+	valid := func() *config.Config {
+		return &config.Config{
+			AtlassianURL:            "https://test.atlassian.net",
+			AtlassianEmail:          "test@example.com",
+			AtlassianToken:          "jira-token",
+			AtlassianStatusReview:   "Code Review",
+			AtlassianStatusDone:     "Done",
+			AtlassianProjectKeys:    []string{"PROJ"},
+			GitHubUsername:          "testuser",
+			GitHubToken:             "gh-token",
+			GitHubRequiredApprovers: 2,
+			GitHubRepos:             []string{"owner/repo"},
+			IssuePattern:            `(PROJ-\d+)`,
+		}
+	}
+
+	tests := map[string]struct {
+		configure func(cfg *config.Config)
+		wantErr   bool
+	}{
+		"valid config": {},
+		"tokens with $": {
+			configure: func(cfg *config.Config) {
+				cfg.AtlassianToken = "jira$HOME${PATH}"
+				cfg.GitHubToken = "gh$$token$"
+				cfg.IssuePattern = `^(PROJ-\d+)$`
+			},
+		},
+		"invalid config": {
+			configure: func(cfg *config.Config) { cfg.GitHubRepos = nil },
+			wantErr:   true,
+		},
+		"invalid issue pattern": {
+			configure: func(cfg *config.Config) { cfg.IssuePattern = `(PROJ-\d+` },
+			wantErr:   true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := valid()
+			if tt.configure != nil {
+				tt.configure(cfg)
+			}
+
+			path := filepath.Join(t.TempDir(), "workflow-monitor", "config.yml")
+			err := writeConfig(path, cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("writeConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if _, err := os.Stat(path); !os.IsNotExist(err) {
+					t.Error("Expected no file to be written")
+				}
+				return
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if perm := info.Mode().Perm(); perm != 0o600 {
+				t.Errorf("Expected permissions 0600, got %o", perm)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(string(data), configHeader) {
+				t.Errorf("Expected the header comment, got %q", data)
+			}
+
+			loaded, err := config.Load(path, "")
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if loaded.IssuePattern != cfg.IssuePattern || loaded.GitHubRequiredApprovers != 2 || !slices.Equal(loaded.GitHubRepos, cfg.GitHubRepos) {
+				t.Errorf("Expected %+v, got %+v", cfg, loaded)
+			}
+			if loaded.AtlassianToken != cfg.AtlassianToken || loaded.GitHubToken != cfg.GitHubToken {
+				t.Errorf("Expected tokens %q and %q, got %q and %q", cfg.AtlassianToken, cfg.GitHubToken, loaded.AtlassianToken, loaded.GitHubToken)
+			}
+		})
+	}
+}
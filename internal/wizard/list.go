@@ -0,0 +1,166 @@
+package wizard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Rows of a list shown at once.
+const listHeight = 10
+
+type option struct {
+	value string
+	label string
+}
+
+// selectList picks one option, or several when multi is set. Multi-select lists
+// can be filtered by typing, which is why options are toggled with space.
+type selectList struct {
+	options  []option
+	multi    bool
+	cursor   int
+	selected map[string]bool
+	filter   textinput.Model
+}
+
+func newSelectList(options []option, multi bool, selected []string) *selectList {
+	filter := textinput.New()
+	filter.Prompt = "Filter: "
+	filter.Focus()
+
+	l := &selectList{
+		options:  options,
+		multi:    multi,
+		selected: make(map[string]bool, len(selected)),
+		filter:   filter,
+	}
+	for _, value := range selected {
+		l.selected[value] = true
+	}
+
+	// Single-select lists start on the current value
+	if !multi && len(selected) > 0 {
+		for i, o := range options {
+			if o.value == selected[0] {
+				l.cursor = i
+			}
+		}
+	}
+
+	return l
+}
+
+func (l *selectList) visible() []option {
+	query := strings.ToLower(l.filter.Value())
+	if !l.multi || query == "" {
+		return l.options
+	}
+
+	var visible []option
+	for _, o := range l.options {
+		if strings.Contains(strings.ToLower(o.label), query) {
+			visible = append(visible, o)
+		}
+	}
+
+	return visible
+}
+
+func (l *selectList) update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "ctrl+p":
+		if l.cursor > 0 {
+			l.cursor--
+		}
+		return nil
+	case "down", "ctrl+n":
+		if l.cursor < len(l.visible())-1 {
+			l.cursor++
+		}
+		return nil
+	case " ":
+		if current, ok := l.current(); ok && l.multi {
+			l.selected[current.value] = !l.selected[current.value]
+		}
+		return nil
+	}
+
+	if !l.multi {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	l.filter, cmd = l.filter.Update(msg)
+	l.cursor = min(l.cursor, max(len(l.visible())-1, 0))
+
+	return cmd
+}
+
+func (l *selectList) current() (option, bool) {
+	visible := l.visible()
+	if l.cursor >= len(visible) {
+		return option{}, false
+	}
+
+	return visible[l.cursor], true
+}
+
+// values returns the selected options in their original order.
+func (l *selectList) values() []string {
+	var values []string
+	for _, o := range l.options {
+		if l.selected[o.value] {
+			values = append(values, o.value)
+		}
+	}
+
+	return values
+}
+
+func (l *selectList) view() string {
+	var b strings.Builder
+	if l.multi {
+		b.WriteString(l.filter.View() + "\n\n")
+	}
+
+	visible := l.visible()
+	if len(visible) == 0 {
+		b.WriteString(subtleStyle.Render("  No matches") + "\n")
+		return b.String()
+	}
+
+	// Keeps the cursor in the middle of the window when possible
+	start := max(0, min(l.cursor-listHeight/2, len(visible)-listHeight))
+	end := min(start+listHeight, len(visible))
+
+	for i := start; i < end; i++ {
+		o := visible[i]
+
+		prefix := "  "
+		if i == l.cursor {
+			prefix = cursorStyle.Render("> ")
+		}
+
+		checkbox := ""
+		if l.multi {
+			checkbox = "[ ] "
+			if l.selected[o.value] {
+				checkbox = selectedStyle.Render("[x]") + " "
+			}
+		}
+
+		b.WriteString(prefix + checkbox + o.label + "\n")
+	}
+
+	if len(visible) > listHeight {
+		b.WriteString(subtleStyle.Render(fmt.Sprintf("  %d-%d of %d", start+1, end, len(visible))) + "\n")
+	}
+	if l.multi {
+		b.WriteString(subtleStyle.Render(fmt.Sprintf("  %d selected", len(l.values()))) + "\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,127 @@
+package wizard
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyMsg returns the message of a named key, or of typed text.
+func keyMsg(key string) tea.KeyMsg {
+	switch key {
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "space":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	case "backspace":
+		return tea.KeyMsg{Type: tea.KeyBackspace}
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	default:
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+}
+
+func TestSelectList(t *testing.T) {
+	options := []option{
+		{value: "PROJ", label: "PROJ - Project"},
+		{value: "OPS", label: "OPS - Operations"},
+		{value: "WEB", label: "WEB - Website"},
+	}
+
+	tests := map[string]struct {
+		multi        bool
+		selected     []string
+		keys         []string
+		wantVisible  []string
+		wantCurrent  string
+		wantSelected []string
+	}{
+		"single starts on the current value": {
+			selected:     []string{"OPS"},
+			wantVisible:  []string{"PROJ", "OPS", "WEB"},
+			wantCurrent:  "OPS",
+			wantSelected: []string{"OPS"},
+		},
+		"single moves within bounds": {
+			keys:        []string{"down", "down", "down", "up"},
+			wantVisible: []string{"PROJ", "OPS", "WEB"},
+			wantCurrent: "OPS",
+		},
+		"single ignores typing and space": {
+			keys:        []string{"web", "space"},
+			wantVisible: []string{"PROJ", "OPS", "WEB"},
+			wantCurrent: "PROJ",
+		},
+		"multi keeps the initial selection": {
+			multi:        true,
+			selected:     []string{"WEB", "PROJ"},
+			wantVisible:  []string{"PROJ", "OPS", "WEB"},
+			wantCurrent:  "PROJ",
+			wantSelected: []string{"PROJ", "WEB"},
+		},
+		"multi toggles": {
+			multi:        true,
+			selected:     []string{"PROJ"},
+			keys:         []string{"space", "down", "space", "down", "space", "space"},
+			wantVisible:  []string{"PROJ", "OPS", "WEB"},
+			wantCurrent:  "WEB",
+			wantSelected: []string{"OPS"},
+		},
+		"multi filters by label, ignoring case": {
+			multi:       true,
+			keys:        []string{"oper"},
+			wantVisible: []string{"OPS"},
+			wantCurrent: "OPS",
+		},
+		"filter keeps the cursor in range": {
+			multi:       true,
+			keys:        []string{"down", "down", "o"},
+			wantVisible: []string{"PROJ", "OPS"},
+			wantCurrent: "OPS",
+		},
+		"toggle applies to the filtered option": {
+			multi:        true,
+			keys:         []string{"site", "space", "backspace", "backspace", "backspace", "backspace"},
+			wantVisible:  []string{"PROJ", "OPS", "WEB"},
+			wantCurrent:  "PROJ",
+			wantSelected: []string{"WEB"},
+		},
+		"filter matching nothing": {
+			multi:       true,
+			keys:        []string{"zzz", "space"},
+			wantVisible: nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			l := newSelectList(options, tt.multi, tt.selected)
+			for _, key := range tt.keys {
+				l.update(keyMsg(key))
+			}
+
+			var visible []string
+			for _, o := range l.visible() {
+				visible = append(visible, o.value)
+			}
+			if !slices.Equal(visible, tt.wantVisible) {
+				t.Errorf("Expected visible %v, got %v", tt.wantVisible, visible)
+			}
+
+			current, ok := l.current()
+			if ok != (tt.wantCurrent != "") || current.value != tt.wantCurrent {
+				t.Errorf("Expected current %q, got %q", tt.wantCurrent, current.value)
+			}
+
+			if got := l.values(); !slices.Equal(got, tt.wantSelected) {
+				t.Errorf("Expected selected %v, got %v", tt.wantSelected, got)
+			}
+		})
+	}
+}
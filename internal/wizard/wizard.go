@@ -0,0 +1,495 @@
+package wizard
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pippokairos/workflow-monitor/internal/atlassian"
+	"github.com/pippokairos/workflow-monitor/internal/config"
+)
+
+var ErrCancelled = errors.New("setup cancelled")
+
+const defaultRequiredApprovers = 1
+
+var (
+	primaryColor   = lipgloss.Color("#FFB86C") // Orange
+	secondaryColor = lipgloss.Color("#00FF87") // Bright green
+	errorColor     = lipgloss.Color("#FF5555") // Red
+	subtleColor    = lipgloss.Color("#6272A4") // Gray
+
+	headerStyle   = lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
+	questionStyle = lipgloss.NewStyle().Bold(true)
+	subtleStyle   = lipgloss.NewStyle().Foreground(subtleColor)
+	cursorStyle   = lipgloss.NewStyle().Foreground(secondaryColor).Bold(true)
+	selectedStyle = lipgloss.NewStyle().Foreground(secondaryColor)
+	errorStyle    = lipgloss.NewStyle().Foreground(errorColor)
+)
+
+type step int
+
+const (
+	stepJiraURL step = iota
+	stepJiraEmail
+	stepJiraToken
+	stepProjects
+	stepStatusReview
+	stepStatusDone
+	stepGitHubToken
+	stepRepos
+	stepIssuePattern
+	stepApprovers
+	stepConfirm
+	stepCount
+)
+
+type question struct {
+	title string
+	hint  string
+}
+
+var questions = map[step]question{
+	stepJiraURL:      {"Jira URL", "e.g. https://mycompany.atlassian.net"},
+	stepJiraEmail:    {"Jira email", "The email of your Atlassian account"},
+	stepJiraToken:    {"Jira API token", "Create one at https://id.atlassian.com/manage-profile/security/api-tokens"},
+	stepProjects:     {"Jira projects", "The projects whose tickets you work on"},
+	stepStatusReview: {"Review status", "The status of tickets whose PRs are in review"},
+	stepStatusDone:   {"Done status", "The status of tickets whose work is done"},
+	stepGitHubToken:  {"GitHub token", "A personal access token with the repo scope, see https://github.com/settings/tokens"},
+	stepRepos:        {"GitHub repos", "The repos your PRs are opened in"},
+	stepIssuePattern: {"Issue pattern", "Regular expression finding ticket keys in branch names"},
+	stepApprovers:    {"Required approvers", "Approvals a PR needs before its ticket can go to QA"},
+	stepConfirm:      {"Write the config?", ""},
+}
+
+type model struct {
+	path string
+	step step
+	cfg  config.Config
+
+	input   textinput.Model
+	list    *selectList
+	spinner spinner.Model
+	loading string
+	err     error
+
+	projects []atlassian.Project
+	statuses []string
+	repos    []string
+
+	written bool
+}
+
+// Run asks for the settings, then writes them to path. It returns ErrCancelled
+// when the user quits before the file is written.
+func Run(path string) error {
+	final, err := tea.NewProgram(newModel(path)).Run()
+	if err != nil {
+		return err
+	}
+
+	if !final.(model).written {
+		return ErrCancelled
+	}
+
+	return nil
+}
+
+func newModel(path string) model {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(primaryColor)
+
+	m := model{
+		path:    path,
+		spinner: s,
+		cfg:     config.Config{GitHubRequiredApprovers: defaultRequiredApprovers},
+	}
+	m.enter(stepJiraURL)
+
+	return m
+}
+
+func (m model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// enter shows the question of the step, pre-filled with the current answer.
+func (m *model) enter(s step) tea.Cmd {
+	m.step = s
+	m.list = nil
+
+	switch s {
+	case stepJiraURL:
+		return m.ask(m.cfg.AtlassianURL, false)
+	case stepJiraEmail:
+		return m.ask(m.cfg.AtlassianEmail, false)
+	case stepJiraToken:
+		return m.ask(m.cfg.AtlassianToken, true)
+	case stepProjects:
+		options := make([]option, len(m.projects))
+		for i, p := range m.projects {
+			options[i] = option{value: p.Key, label: fmt.Sprintf("%s - %s", p.Key, p.Name)}
+		}
+		m.list = newSelectList(options, true, m.cfg.AtlassianProjectKeys)
+	case stepStatusReview:
+		m.list = newSelectList(statusOptions(m.statuses), false, []string{m.cfg.AtlassianStatusReview})
+	case stepStatusDone:
+		m.list = newSelectList(statusOptions(m.statuses), false, []string{m.cfg.AtlassianStatusDone})
+	case stepGitHubToken:
+		return m.ask(m.cfg.GitHubToken, true)
+	case stepRepos:
+		options := make([]option, len(m.repos))
+		for i, repo := range m.repos {
+			options[i] = option{value: repo, label: repo}
+		}
+		m.list = newSelectList(options, true, m.cfg.GitHubRepos)
+	case stepIssuePattern:
+		pattern := m.cfg.IssuePattern
+		if pattern == "" {
+			pattern = ProposeIssuePattern(m.cfg.AtlassianProjectKeys)
+		}
+		return m.ask(pattern, false)
+	case stepApprovers:
+		return m.ask(strconv.Itoa(m.cfg.GitHubRequiredApprovers), false)
+	}
+
+	return nil
+}
+
+func (m *model) ask(value string, secret bool) tea.Cmd {
+	m.input = textinput.New()
+	m.input.SetValue(value)
+	if secret {
+		m.input.EchoMode = textinput.EchoPassword
+	}
+
+	return m.input.Focus()
+}
+
+func statusOptions(statuses []string) []option {
+	options := make([]option, len(statuses))
+	for i, status := range statuses {
+		options[i] = option{value: status, label: status}
+	}
+
+	return options
+}
+
+// load shows a spinner until the command's message arrives.
+func (m *model) load(message string, cmd tea.Cmd) tea.Cmd {
+	m.loading = message
+	return tea.Batch(m.spinner.Tick, cmd)
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.updateKey(msg)
+
+	case spinner.TickMsg:
+		if m.loading == "" {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case projectsLoadedMsg:
+		m.loading = ""
+		if msg.err == nil && len(msg.projects) == 0 {
+			msg.err = errors.New("no Jira projects are visible with these credentials")
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			return m, m.enter(stepJiraToken)
+		}
+		m.projects = msg.projects
+		return m, m.enter(stepProjects)
+
+	case statusesLoadedMsg:
+		m.loading = ""
+		if msg.err != nil {
+			m.err = msg.err
+			return m, m.enter(stepProjects)
+		}
+		m.statuses = msg.statuses
+		return m, m.enter(stepStatusReview)
+
+	case githubLoadedMsg:
+		m.loading = ""
+		if msg.err == nil && len(msg.repos) == 0 {
+			msg.err = errors.New("no GitHub repos are accessible with this token")
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			return m, m.enter(stepGitHubToken)
+		}
+		m.cfg.GitHubUsername = msg.login
+		m.repos = msg.repos
+		return m, m.enter(stepRepos)
+
+	case configWrittenMsg:
+		m.loading = ""
+		if msg.err != nil {
+			m.err = fmt.Errorf("failed to write %s: %w", m.path, msg.err)
+			return m, nil
+		}
+		m.written = true
+		return m, tea.Quit
+	}
+
+	// Keeps the cursor blinking
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m model) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	// Answers can't change while they're being checked
+	if m.loading != "" {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		if m.step == stepJiraURL {
+			return m, tea.Quit
+		}
+		m.err = nil
+		return m, m.enter(m.step - 1)
+	case "enter":
+		m.err = nil
+		return m.submit()
+	}
+
+	if m.list != nil {
+		return m, m.list.update(msg)
+	}
+
+	if m.step == stepConfirm {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// submit saves the answer to the current question and moves to the next one.
+func (m model) submit() (tea.Model, tea.Cmd) {
+	value := strings.TrimSpace(m.input.Value())
+
+	switch m.step {
+	case stepJiraURL:
+		jiraURL, err := normalizeURL(value)
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.cfg.AtlassianURL = jiraURL
+
+	case stepJiraEmail:
+		if value == "" {
+			m.err = errors.New("the email is required")
+			return m, nil
+		}
+		m.cfg.AtlassianEmail = value
+
+	case stepJiraToken:
+		if value == "" {
+			m.err = errors.New("the token is required")
+			return m, nil
+		}
+		m.cfg.AtlassianToken = value
+		return m, m.load("Fetching your Jira projects", fetchProjectsCmd(m.cfg))
+
+	case stepProjects:
+		keys := m.list.values()
+		if len(keys) == 0 {
+			m.err = errors.New("select at least one project with space")
+			return m, nil
+		}
+		// The proposed pattern depends on the projects
+		if !slices.Equal(keys, m.cfg.AtlassianProjectKeys) {
+			m.cfg.IssuePattern = ""
+		}
+		m.cfg.AtlassianProjectKeys = keys
+		return m, m.load("Fetching the workflow statuses", fetchStatusesCmd(m.cfg))
+
+	case stepStatusReview, stepStatusDone:
+		current, ok := m.list.current()
+		if !ok {
+			return m, nil
+		}
+		if m.step == stepStatusReview {
+			m.cfg.AtlassianStatusReview = current.value
+		} else {
+			if current.value == m.cfg.AtlassianStatusReview {
+				m.err = errors.New("the done status must differ from the review status")
+				return m, nil
+			}
+			m.cfg.AtlassianStatusDone = current.value
+		}
+
+	case stepGitHubToken:
+		if value == "" {
+			m.err = errors.New("the token is required")
+			return m, nil
+		}
+		m.cfg.GitHubToken = value
+		return m, m.load("Fetching your GitHub repos", fetchGitHubCmd(m.cfg))
+
+	case stepRepos:
+		repos := m.list.values()
+		if len(repos) == 0 {
+			m.err = errors.New("select at least one repo with space")
+			return m, nil
+		}
+		m.cfg.GitHubRepos = repos
+
+	case stepIssuePattern:
+		if value == "" {
+			m.err = errors.New("the pattern is required")
+			return m, nil
+		}
+		if _, err := regexp.Compile(value); err != nil {
+			m.err = fmt.Errorf("invalid regular expression: %v", err)
+			return m, nil
+		}
+		m.cfg.IssuePattern = value
+
+	case stepApprovers:
+		approvers, err := strconv.Atoi(value)
+		if err != nil || approvers < 0 {
+			m.err = errors.New("enter a number, 0 or more")
+			return m, nil
+		}
+		m.cfg.GitHubRequiredApprovers = approvers
+
+	case stepConfirm:
+		return m, m.load("Writing "+m.path, writeConfigCmd(m.path, m.cfg))
+	}
+
+	return m, m.enter(m.step + 1)
+}
+
+// normalizeURL adds the https scheme when missing and drops trailing slashes.
+func normalizeURL(rawURL string) (string, error) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	rawURL = strings.TrimRight(rawURL, "/")
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", errors.New("enter an http or https URL, e.g. https://mycompany.atlassian.net")
+	}
+
+	return rawURL, nil
+}
+
+func (m model) View() string {
+	if m.written {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(headerStyle.Render("workflow-monitor init") + subtleStyle.Render(fmt.Sprintf("  step %d of %d", m.step+1, stepCount)) + "\n\n")
+
+	q := questions[m.step]
+	b.WriteString(questionStyle.Render(q.title) + "\n")
+	if q.hint != "" {
+		b.WriteString(subtleStyle.Render(q.hint) + "\n")
+	}
+	b.WriteString("\n")
+
+	switch {
+	case m.step == stepConfirm:
+		b.WriteString(m.renderSummary())
+	case m.list != nil:
+		b.WriteString(m.list.view())
+	default:
+		b.WriteString(m.input.View() + "\n")
+	}
+
+	b.WriteString("\n")
+	if m.loading != "" {
+		b.WriteString(m.spinner.View() + " " + m.loading + "...\n\n")
+	}
+	if m.err != nil {
+		b.WriteString(errorStyle.Render(m.err.Error()) + "\n\n")
+	}
+
+	b.WriteString(subtleStyle.Render(m.helpLine()) + "\n")
+
+	return b.String()
+}
+
+func (m model) renderSummary() string {
+	rows := []struct {
+		name  string
+		value string
+	}{
+		{"Jira URL", m.cfg.AtlassianURL},
+		{"Jira email", m.cfg.AtlassianEmail},
+		{"Jira token", maskToken(m.cfg.AtlassianToken)},
+		{"Jira projects", strings.Join(m.cfg.AtlassianProjectKeys, ", ")},
+		{"Review status", m.cfg.AtlassianStatusReview},
+		{"Done status", m.cfg.AtlassianStatusDone},
+		{"GitHub user", m.cfg.GitHubUsername},
+		{"GitHub token", maskToken(m.cfg.GitHubToken)},
+		{"GitHub repos", strings.Join(m.cfg.GitHubRepos, ", ")},
+		{"Issue pattern", m.cfg.IssuePattern},
+		{"Required approvers", strconv.Itoa(m.cfg.GitHubRequiredApprovers)},
+	}
+
+	var b strings.Builder
+	for _, row := range rows {
+		b.WriteString(fmt.Sprintf("  %-20s %s\n", row.name, row.value))
+	}
+	b.WriteString("\n" + subtleStyle.Render("The config will be written to "+m.path) + "\n")
+
+	return b.String()
+}
+
+// maskToken hides all but the last characters of a token.
+func maskToken(token string) string {
+	const visible = 4
+	if len(token) <= visible {
+		return strings.Repeat("*", len(token))
+	}
+
+	return strings.Repeat("*", 8) + token[len(token)-visible:]
+}
+
+func (m model) helpLine() string {
+	back := "esc back"
+	if m.step == stepJiraURL {
+		back = "esc quit"
+	}
+
+	switch {
+	case m.step == stepConfirm:
+		return "enter write • " + back + " • ctrl+c quit"
+	case m.list != nil && m.list.multi:
+		return "↑/↓ move • space toggle • type to filter • enter next • " + back + " • ctrl+c quit"
+	case m.list != nil:
+		return "↑/↓ move • enter select • " + back + " • ctrl+c quit"
+	default:
+		return "enter next • " + back + " • ctrl+c quit"
+	}
+}
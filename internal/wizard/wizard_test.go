@@ -0,0 +1,209 @@
+package wizard
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/pippokairos/workflow-monitor/internal/atlassian"
+)
+
+// send updates the model with each message, returning the command of the last one.
+func send(t *testing.T, m model, msgs ...tea.Msg) (model, tea.Cmd) {
+	t.Helper()
+
+	var cmd tea.Cmd
+	for _, msg := range msgs {
+		var updated tea.Model
+		updated, cmd = m.Update(msg)
+		m = updated.(model)
+	}
+
+	return m, cmd
+}
+
+func keys(names ...string) []tea.Msg {
+	msgs := make([]tea.Msg, len(names))
+	for i, name := range names {
+		msgs[i] = keyMsg(name)
+	}
+	return msgs
+}
+
+func TestWizardSteps(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	m := newModel(path)
+
+	m, _ = send(t, m, keys("test.atlassian.net/", "enter")...)
+	if m.step != stepJiraEmail || m.cfg.AtlassianURL != "https://test.atlassian.net" {
+		t.Fatalf("Expected the email step with a normalized URL, got step %d and %q", m.step, m.cfg.AtlassianURL)
+	}
+
+	// The token is checked by fetching the projects, during which answers can't change
+	m, _ = send(t, m, keys("test@example.com", "enter", "jira-token", "enter")...)
+	if m.step != stepJiraToken || m.loading == "" {
+		t.Fatalf("Expected the projects to be loading, got step %d", m.step)
+	}
+	m, _ = send(t, m, keys("x", "esc")...)
+	if m.step != stepJiraToken || m.input.Value() != "jira-token" {
+		t.Fatalf("Expected keys to be ignored while loading, got step %d and %q", m.step, m.input.Value())
+	}
+
+	m, _ = send(t, m, projectsLoadedMsg{projects: []atlassian.Project{{Key: "PROJ", Name: "Project"}, {Key: "OPS", Name: "Operations"}}})
+	if m.step != stepProjects || m.loading != "" {
+		t.Fatalf("Expected the projects step, got step %d", m.step)
+	}
+
+	m, _ = send(t, m, keys("enter")...)
+	if m.step != stepProjects || m.err == nil {
+		t.Fatalf("Expected an error without any project selected, got step %d", m.step)
+	}
+
+	m, _ = send(t, m, keys("space", "enter")...)
+	m, _ = send(t, m, statusesLoadedMsg{statuses: []string{"To Do", "Code Review", "Done"}})
+	if m.step != stepStatusReview || !slices.Equal(m.cfg.AtlassianProjectKeys, []string{"PROJ"}) {
+		t.Fatalf("Expected the review status step with PROJ, got step %d and %v", m.step, m.cfg.AtlassianProjectKeys)
+	}
+
+	m, _ = send(t, m, keys("down", "enter", "down", "enter")...)
+	if m.step != stepStatusDone || m.err == nil {
+		t.Fatalf("Expected an error when the done status is the review one, got step %d", m.step)
+	}
+
+	m, _ = send(t, m, keys("down", "enter", "gh-token", "enter")...)
+	m, _ = send(t, m, githubLoadedMsg{login: "testuser", repos: []string{"owner/api", "owner/web"}})
+	if m.step != stepRepos || m.cfg.AtlassianStatusReview != "Code Review" || m.cfg.AtlassianStatusDone != "Done" {
+		t.Fatalf("Expected the repos step with both statuses, got step %d and %+v", m.step, m.cfg)
+	}
+
+	m, _ = send(t, m, keys("web", "space", "enter")...)
+	if m.step != stepIssuePattern || m.input.Value() != `(PROJ-\d+)` {
+		t.Fatalf("Expected the issue pattern step proposing (PROJ-\\d+), got step %d and %q", m.step, m.input.Value())
+	}
+
+	m, _ = send(t, m, keys("enter", "backspace", "2", "enter")...)
+	if m.step != stepConfirm || m.cfg.GitHubRequiredApprovers != 2 {
+		t.Fatalf("Expected the confirmation step with 2 approvers, got step %d and %d", m.step, m.cfg.GitHubRequiredApprovers)
+	}
+
+	m, cmd := send(t, m, keys("enter")...)
+	if cmd == nil || m.loading == "" {
+		t.Fatal("Expected the config to be written")
+	}
+
+	m, _ = send(t, m, configWrittenMsg{})
+	if !m.written {
+		t.Error("Expected the config to be written")
+	}
+
+	want := []string{"owner/web"}
+	if m.cfg.GitHubUsername != "testuser" || !slices.Equal(m.cfg.GitHubRepos, want) || m.cfg.GitHubToken != "gh-token" {
+		t.Errorf("Unexpected GitHub settings: %+v", m.cfg)
+	}
+}
+
+func TestWizardLoadErrors(t *testing.T) {
+	tests := map[string]struct {
+		step     step
+		msg      tea.Msg
+		wantStep step
+	}{
+		"Jira credentials rejected": {
+			step:     stepJiraToken,
+			msg:      projectsLoadedMsg{err: errors.New("401 Unauthorized")},
+			wantStep: stepJiraToken,
+		},
+		"no Jira project visible": {
+			step:     stepJiraToken,
+			msg:      projectsLoadedMsg{},
+			wantStep: stepJiraToken,
+		},
+		"statuses not loaded": {
+			step:     stepProjects,
+			msg:      statusesLoadedMsg{err: errors.New("project not found")},
+			wantStep: stepProjects,
+		},
+		"GitHub token rejected": {
+			step:     stepGitHubToken,
+			msg:      githubLoadedMsg{err: errors.New("401 Bad credentials")},
+			wantStep: stepGitHubToken,
+		},
+		"no GitHub repo accessible": {
+			step:     stepGitHubToken,
+			msg:      githubLoadedMsg{login: "testuser"},
+			wantStep: stepGitHubToken,
+		},
+		"config not written": {
+			step:     stepConfirm,
+			msg:      configWrittenMsg{err: errors.New("permission denied")},
+			wantStep: stepConfirm,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := newModel("config.yml")
+			m.enter(tt.step)
+			m.loading = "Loading"
+
+			m, _ = send(t, m, tt.msg)
+			if m.step != tt.wantStep || m.err == nil || m.loading != "" || m.written {
+				t.Errorf("Expected an error on step %d, got step %d, error %v", tt.wantStep, m.step, m.err)
+			}
+		})
+	}
+}
+
+func TestWizardSubmitValidation(t *testing.T) {
+	tests := map[string]struct {
+		step    step
+		value   string
+		wantErr bool
+	}{
+		"URL without scheme":    {step: stepJiraURL, value: "test.atlassian.net"},
+		"URL of another scheme": {step: stepJiraURL, value: "ftp://test.atlassian.net", wantErr: true},
+		"empty URL":             {step: stepJiraURL, value: " ", wantErr: true},
+		"empty email":           {step: stepJiraEmail, value: "", wantErr: true},
+		"valid pattern":         {step: stepIssuePattern, value: `(PROJ-\d+)`},
+		"invalid pattern":       {step: stepIssuePattern, value: `(PROJ-\d+`, wantErr: true},
+		"no approvers":          {step: stepApprovers, value: "0"},
+		"negative approvers":    {step: stepApprovers, value: "-1", wantErr: true},
+		"approvers not numeric": {step: stepApprovers, value: "two", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := newModel("config.yml")
+			m.enter(tt.step)
+			m.input.SetValue(tt.value)
+
+			m, _ = send(t, m, keyMsg("enter"))
+			if (m.err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, m.err)
+			}
+
+			wantStep := tt.step + 1
+			if tt.wantErr {
+				wantStep = tt.step
+			}
+			if m.step != wantStep {
+				t.Errorf("Expected step %d, got %d", wantStep, m.step)
+			}
+		})
+	}
+}
+
+func TestWizardBack(t *testing.T) {
+	m := newModel("config.yml")
+	m, cmd := send(t, m, keyMsg("esc"))
+	if cmd == nil || cmd() != tea.Quit() {
+		t.Error("Expected esc on the first step to quit")
+	}
+
+	m, _ = send(t, m, keys("test.atlassian.net", "enter", "esc")...)
+	if m.step != stepJiraURL || m.input.Value() != "https://test.atlassian.net" {
+		t.Errorf("Expected to go back to the URL pre-filled, got step %d and %q", m.step, m.input.Value())
+	}
+}